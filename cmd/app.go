@@ -39,9 +39,17 @@ type CLI struct {
 	LogSource  bool                  `kong:"optional,group='logging',env='LOG_SOURCE',hidden,help='log source (filename and line number)'"`
 	CPUProfile *cpuprofiler.Profiler `kong:"optional,hidden,help='profile cpu destination',placeholder='FILENAME'"`
 
+	// configFile is the path of the repository configuration file, if one was loaded.
+	configFile string
+	// configWarnings are problems with the configuration file that didn't prevent it from being loaded.
+	configWarnings []error
+
 	// Commands:
 	Lint        LintCommand        `kong:"cmd,default='',help='lint the commit messages in a git repository'"`
 	NextVersion NextVersionCommand `kong:"cmd,help='get next version (lint is recommended prior to running this)'"`
+	Hooks       HooksCommand       `kong:"cmd,help='manage the git hooks that run commit-tool'"`
+	ReceiveHook ReceiveHookCommand `kong:"cmd,name='receive-hook',help='lint the commits of a push in a pre-receive or update hook of a (bare) repository'"`
+	Rules       RulesCommand       `kong:"cmd,help='list and explain the rules'"`
 	Version     VersionCommand     `kong:"cmd,help='show program version'"`
 }
 
@@ -61,6 +69,11 @@ func Run() StatusCode {
 				Title:       "Logging",
 				Description: "Logging configuration",
 			},
+			{
+				Key:         "policy",
+				Title:       "Policy",
+				Description: "Commit message policy, normally set in the repository configuration file",
+			},
 		}),
 	)
 
@@ -110,6 +123,19 @@ func Run() StatusCode {
 		defer stopCPUProfiler()
 	}
 
+	if cli.configFile != "" && logger.Enabled(ctx, slog.LevelDebug) {
+		logger.LogAttrs(ctx, slog.LevelDebug, "loaded configuration",
+			slog.String("path", cli.configFile),
+		)
+	}
+
+	for _, warning := range cli.configWarnings {
+		logger.LogAttrs(ctx, slog.LevelWarn, "ignoring configuration",
+			slog.String("path", cli.configFile),
+			slog.String("warning", warning.Error()),
+		)
+	}
+
 	command.BindTo(ctx, (*context.Context)(nil))
 	command.Bind(logger.With("logger", command.Selected().Name))
 
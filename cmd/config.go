@@ -0,0 +1,104 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/alecthomas/kong"
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-git/v5"
+
+	"codeberg.org/somebadcode/commit-tool/config"
+)
+
+var (
+	ErrUnknownRepositoryLocation = errors.New("repository is not stored on a filesystem")
+)
+
+// BeforeResolve loads the configuration file of the selected command's repository, so that it can provide the values
+// of flags that were not set on the command-line.
+func (cli *CLI) BeforeResolve(ctx *kong.Context) error {
+	repo := selectedRepository(ctx)
+	if repo == nil {
+		return nil
+	}
+
+	dir, err := repositoryDir(repo)
+	if err != nil {
+		return fmt.Errorf("cannot locate configuration: %w", err)
+	}
+
+	file, err := config.Load(dir)
+	if errors.Is(err, config.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	ctx.AddResolver(file.Resolver)
+	cli.configFile = file.Path
+	cli.configWarnings = file.Warnings
+
+	return nil
+}
+
+// selectedRepository returns the repository of the selected command, preferring a repository given on the
+// command-line over the default one.
+func selectedRepository(ctx *kong.Context) *git.Repository {
+	node := ctx.Selected()
+	if node == nil {
+		return nil
+	}
+
+	repoType := reflect.TypeOf((*git.Repository)(nil))
+
+	for _, path := range ctx.Path {
+		if (path.Flag != nil && path.Flag.Target.Type() == repoType) ||
+			(path.Positional != nil && path.Positional.Target.Type() == repoType) {
+			if repo, ok := ctx.Value(path).Interface().(*git.Repository); ok && repo != nil {
+				return repo
+			}
+		}
+	}
+
+	values := append([]*kong.Value{}, node.Positional...)
+	for _, flag := range node.Flags {
+		values = append(values, flag.Value)
+	}
+
+	for _, value := range values {
+		if value.Target.Type() == repoType {
+			repo, _ := value.Target.Interface().(*git.Repository)
+			return repo
+		}
+	}
+
+	return nil
+}
+
+// repositoryDir returns the root of the repository's working tree, or the git directory if the repository is bare.
+func repositoryDir(repo *git.Repository) (string, error) {
+	tree, err := repo.Worktree()
+	if err == nil {
+		return tree.Filesystem.Root(), nil
+	}
+
+	if !errors.Is(err, git.ErrIsBareRepository) {
+		return "", err
+	}
+
+	storage, ok := repo.Storer.(interface{ Filesystem() billy.Filesystem })
+	if !ok || storage.Filesystem() == nil {
+		return "", ErrUnknownRepositoryLocation
+	}
+
+	return storage.Filesystem().Root(), nil
+}
@@ -0,0 +1,171 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+
+	"codeberg.org/somebadcode/commit-tool/githooks"
+	"codeberg.org/somebadcode/commit-tool/suggest"
+)
+
+type HooksCommand struct {
+	Install        HooksInstallCommand        `kong:"cmd,help='install git hooks that run commit-tool'"`
+	Uninstall      HooksUninstallCommand      `kong:"cmd,help='remove the git hooks that were installed by commit-tool'"`
+	Status         HooksStatusCommand         `kong:"cmd,help='show the status of the git hooks'"`
+	PrepareMessage HooksPrepareMessageCommand `kong:"cmd,hidden,help='prepare a commit message (used by the prepare-commit-msg hook)'"`
+}
+
+type HooksInstallCommand struct {
+	Repository *git.Repository `kong:"placeholder='path',default='.',help='repository to install hooks in'"`
+	Command    string          `kong:"default='commit-tool',placeholder='COMMAND',help='command that the hooks use to run commit-tool'"`
+}
+
+func (cmd *HooksInstallCommand) Run(ctx context.Context, l *slog.Logger) error {
+	dir, err := githooks.Dir(cmd.Repository)
+	if err != nil {
+		return err
+	}
+
+	installer := githooks.Installer{
+		Dir:     dir,
+		Command: cmd.Command,
+		Logger:  l,
+	}
+
+	return installer.Install(ctx)
+}
+
+type HooksUninstallCommand struct {
+	Repository *git.Repository `kong:"placeholder='path',default='.',help='repository to remove hooks from'"`
+}
+
+func (cmd *HooksUninstallCommand) Run(ctx context.Context, l *slog.Logger) error {
+	dir, err := githooks.Dir(cmd.Repository)
+	if err != nil {
+		return err
+	}
+
+	installer := githooks.Installer{
+		Dir: dir,
+		// The command isn't used when uninstalling.
+		Command: "commit-tool",
+		Logger:  l,
+	}
+
+	return installer.Uninstall(ctx)
+}
+
+type HooksStatusCommand struct {
+	Repository *git.Repository `kong:"placeholder='path',default='.',help='repository to show hook status for'"`
+}
+
+func (cmd *HooksStatusCommand) Run(ctx context.Context, l *slog.Logger) error {
+	dir, err := githooks.Dir(cmd.Repository)
+	if err != nil {
+		return err
+	}
+
+	installer := githooks.Installer{
+		Dir: dir,
+	}
+
+	statuses, err := installer.Status()
+	if err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		l.LogAttrs(ctx, slog.LevelInfo, "hook status",
+			slog.String("hook", status.Name),
+			slog.String("path", status.Path),
+			slog.String("state", status.State.String()),
+			slog.Bool("chained", status.Chained),
+		)
+	}
+
+	return nil
+}
+
+type HooksPrepareMessageCommand struct {
+	File       string          `kong:"arg,type='path',help='file containing the commit message'"`
+	Source     string          `kong:"arg,optional,help='source of the commit message'"`
+	Commit     string          `kong:"arg,optional,help='commit that the message was taken from'"`
+	Repository *git.Repository `kong:"placeholder='path',default='.',help='repository that is being committed to'"`
+
+	Policy `kong:"embed,group='policy'"`
+}
+
+func (cmd *HooksPrepareMessageCommand) Run(ctx context.Context, l *slog.Logger) error {
+	// Only a message that git opens in an editor without any prior message is prepared.
+	if cmd.Source != "" {
+		return nil
+	}
+
+	b, err := os.ReadFile(cmd.File)
+	if err != nil {
+		return fmt.Errorf("reading message file: %w", err)
+	}
+
+	types, err := cmd.allowedTypes()
+	if err != nil {
+		return err
+	}
+
+	hints := []string{
+		"Allowed types: " + strings.Join(types, ", "),
+	}
+
+	if scopes := cmd.allowedScopes(); len(scopes) > 0 {
+		hints = append(hints, "Allowed scopes: "+strings.Join(scopes, ", "))
+	}
+
+	hints = append(hints, cmd.suggestionHints(ctx, l)...)
+
+	message := githooks.PrepareMessage(string(b), commentString(cmd.Repository, string(b)), hints)
+
+	if err = os.WriteFile(cmd.File, []byte(message), 0o666); err != nil {
+		return fmt.Errorf("writing message file: %w", err)
+	}
+
+	return nil
+}
+
+// suggestionHints suggest a type and scope from the staged files, if the policy maps types or scopes to paths.
+func (cmd *HooksPrepareMessageCommand) suggestionHints(ctx context.Context, l *slog.Logger) []string {
+	if len(cmd.TypePaths) == 0 && len(cmd.ScopePaths) == 0 {
+		return nil
+	}
+
+	suggestion, err := suggest.New(cmd.TypePaths, cmd.ScopePaths).SuggestStaged(cmd.Repository)
+	if err != nil {
+		// The hints are only a help, the message is prepared without them.
+		if l.Enabled(ctx, slog.LevelDebug) {
+			l.LogAttrs(ctx, slog.LevelDebug, "cannot suggest type and scope",
+				slog.String("error", err.Error()),
+			)
+		}
+
+		return nil
+	}
+
+	switch {
+	case suggestion.Type != "":
+		return []string{"Suggested from the staged files: " + strings.TrimSpace(suggestion.Header())}
+	case suggestion.Scope != "":
+		return []string{"Suggested scope from the staged files: " + suggestion.Scope}
+	}
+
+	return nil
+}
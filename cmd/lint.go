@@ -9,13 +9,14 @@ package cmd
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"os"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 
-	"codeberg.org/somebadcode/commit-tool/commitlinter"
-	"codeberg.org/somebadcode/commit-tool/commitlinter/conventionalcommits"
 	"codeberg.org/somebadcode/commit-tool/linter"
 )
 
@@ -23,21 +24,71 @@ type LintCommand struct {
 	Repository    *git.Repository   `kong:"placeholder='path',default='.',help='repository to lint'"`
 	Revision      plumbing.Revision `kong:"name='revision',aliases='rev',optional,default='HEAD',placeholder='REVISION',help='revision to start at'"`
 	OtherRevision plumbing.Revision `kong:"name='other-revision',aliases='other',optional,placeholder='REVISION',help='revision (actual other) to stop at (exclusive)'"`
+	MessageFile   string            `kong:"name='message-file',type='path',optional,xor='input',placeholder='FILE',help='lint the commit message in FILE (- for stdin) instead of commits, e.g. in a commit-msg hook'"`
+	PrePush       bool              `kong:"name='pre-push',optional,xor='input',help='lint the commits that are about to be pushed, reading the ref updates from stdin like a pre-push hook'"`
+	Format        string            `kong:"name='format',enum='auto,text,log,json,sarif,junit,tap',default='auto',help='report format (${enum}), auto is text if stderr is a terminal and log otherwise, json, sarif, junit and tap are written to stdout'"`
+
+	Policy `kong:"embed,group='policy'"`
 }
 
 func (cmd *LintCommand) Run(ctx context.Context, l *slog.Logger) error {
+	commitLinter, err := cmd.CommitLinter(cmd.Repository)
+	if err != nil {
+		return err
+	}
+
+	rules, err := cmd.RuleDescriptors()
+	if err != nil {
+		return err
+	}
+
+	reporter := newReporter(cmd.Format, os.Stdout, l, rules)
+
 	lint := linter.Linter{
-		Repo:       cmd.Repository,
-		Rev:        cmd.Revision,
-		OtherRev:   cmd.OtherRevision,
-		ReportFunc: linter.SlogReporter(l),
-		CommitLinter: &commitlinter.Linter{
-			Rules: commitlinter.Rules{
-				conventionalcommits.Verify,
-			},
-		},
-		Logger: l,
+		Repo:         cmd.Repository,
+		Rev:          cmd.Revision,
+		OtherRev:     cmd.OtherRevision,
+		ReportFunc:   reporter.Report,
+		PassFunc:     reporter.Pass,
+		CommitLinter: commitLinter,
+		Logger:       l,
 	}
 
+	err = cmd.lint(ctx, l, lint, reporter)
+
+	if finishErr := reporter.Finish(); finishErr != nil {
+		return errors.Join(err, fmt.Errorf("writing report: %w", finishErr))
+	}
+
+	return err
+}
+
+func (cmd *LintCommand) lint(ctx context.Context, l *slog.Logger, lint linter.Linter, reporter linter.Reporter) error {
+	switch {
+	case cmd.MessageFile != "":
+		message, err := readMessage(cmd.Repository, cmd.MessageFile)
+		if err != nil {
+			return err
+		}
+
+		if cmd.MessageFile == "-" {
+			reporter.BeginRange("stdin")
+		} else {
+			reporter.BeginRange(cmd.MessageFile)
+		}
+
+		return lint.LintCommit(ctx, pendingCommit(cmd.Repository, message))
+
+	case cmd.PrePush:
+		return lintPushUpdates(ctx, l, lint, reporter)
+	}
+
+	name := cmd.Revision.String()
+	if cmd.OtherRevision != "" {
+		name = cmd.OtherRevision.String() + ".." + name
+	}
+
+	reporter.BeginRange(name)
+
 	return lint.Run(ctx)
 }
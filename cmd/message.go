@@ -0,0 +1,112 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+	"codeberg.org/somebadcode/commit-tool/internal/gitconfig"
+)
+
+// readMessage reads a commit message from a file, or from stdin if the filename is "-", and cleans it up the same way
+// git does before committing.
+func readMessage(repo *git.Repository, filename string) (string, error) {
+	var r io.Reader = os.Stdin
+
+	if filename != "-" {
+		f, err := os.Open(filename)
+		if err != nil {
+			return "", fmt.Errorf("opening message file: %w", err)
+		}
+
+		defer func() {
+			_ = f.Close()
+		}()
+
+		r = f
+	}
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("reading message: %w", err)
+	}
+
+	message := string(b)
+
+	return commitparser.Cleanup(message, commentString(repo, message)), nil
+}
+
+// commentString returns the string that starts comment lines in a message that git has opened in an editor.
+func commentString(repo *git.Repository, message string) string {
+	s := gitconfig.Option(repo, "core", "commentString")
+	if s == "" {
+		s = gitconfig.Option(repo, "core", "commentChar")
+	}
+
+	switch s {
+	case "":
+		return commitparser.DefaultCommentString
+	case "auto":
+		return commitparser.DetectCommentString(message)
+	}
+
+	return s
+}
+
+// pendingCommit creates a commit object for a message that hasn't been committed yet. The author and committer are
+// taken from the environment or the git configuration, the same way git would.
+func pendingCommit(repo *git.Repository, message string) *object.Commit {
+	var name, email string
+
+	if cfg, err := repo.ConfigScoped(config.SystemScope); err == nil {
+		name, email = cfg.User.Name, cfg.User.Email
+
+		if cfg.Author.Name != "" {
+			name = cfg.Author.Name
+		}
+
+		if cfg.Author.Email != "" {
+			email = cfg.Author.Email
+		}
+	}
+
+	if v, ok := os.LookupEnv("GIT_AUTHOR_NAME"); ok {
+		name = v
+	}
+
+	if v, ok := os.LookupEnv("GIT_AUTHOR_EMAIL"); ok {
+		email = v
+	}
+
+	signature := object.Signature{
+		Name:  name,
+		Email: email,
+		When:  time.Now(),
+	}
+
+	commit := &object.Commit{
+		Author:    signature,
+		Committer: signature,
+		Message:   message,
+	}
+
+	// The commit will become a child of HEAD, unless it's the initial commit.
+	if head, err := repo.Head(); err == nil {
+		commit.ParentHashes = append(commit.ParentHashes, head.Hash())
+	}
+
+	return commit
+}
@@ -27,14 +27,20 @@ type NextVersionCommand struct {
 	VSuffix        bool              `kong:"default='true',negatable,help='output with v-suffix, i.e. v1.3.2'"`
 	WithPrerelease string            `kong:"optional,help='add prerelease information to tag'"`
 	WithMetadata   string            `kong:"optional,help='add metadata to tag'"`
+
+	Policy `kong:"embed,group='policy'"`
 }
 
 func (cmd *NextVersionCommand) Run(ctx context.Context, l *slog.Logger) error {
+	bumps, err := cmd.versionBumps()
+	if err != nil {
+		return err
+	}
+
 	var f io.WriteCloser
 	if cmd.Output == "-" {
 		f = os.Stdout
 	} else {
-		var err error
 		f, err = os.OpenFile(cmd.Output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o666)
 		if err != nil {
 			return fmt.Errorf("opening output file: %w", err)
@@ -53,6 +59,7 @@ func (cmd *NextVersionCommand) Run(ctx context.Context, l *slog.Logger) error {
 		Metadata:   cmd.WithMetadata,
 		Writer:     f,
 		Logger:     l,
+		Bumps:      bumps,
 	}
 
 	return next.Run(ctx)
@@ -0,0 +1,226 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/go-git/go-git/v5"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitlinter/conventionalcommits"
+	"codeberg.org/somebadcode/commit-tool/config"
+	"codeberg.org/somebadcode/commit-tool/linter"
+	"codeberg.org/somebadcode/commit-tool/mailmap"
+	"codeberg.org/somebadcode/commit-tool/nextversion"
+)
+
+// Policy is the commit message policy of a repository. It is normally set using the repository configuration file.
+type Policy struct {
+	Types      []string     `kong:"name='types',sep=',',placeholder='TYPE',help='allowed commit types (defaults to the conventional commit types)'"`
+	Scopes     []string     `kong:"name='scopes',sep=',',placeholder='SCOPE',help='allowed commit scopes (defaults to the scopes of scope-paths, any scope is allowed if empty)'"`
+	ScopePaths config.Paths `kong:"name='scope-paths',placeholder='SCOPE=PATTERN',help='paths that scopes cover, as gitignore-style patterns'"`
+	TypePaths  config.Paths `kong:"name='type-paths',placeholder='TYPE=PATTERN',help='paths that types cover, as gitignore-style patterns (used to suggest types)'"`
+	Rules      config.Rules `kong:"name='rules',placeholder='ID=BOOL',help='enable or disable rules'"`
+	Bumps      config.Bumps `kong:"name='bumps',toplevel,placeholder='TYPE=BUMP',help='version bumps of types, in addition to feat=minor,fix=patch,sec=patch (the types are allowed too)'"`
+
+	Suppressible []string `kong:"name='suppressible',sep=',',default='*',placeholder='ID',help='rules that a commit message can suppress using a Lint-Ignore trailer (* for any rule)'"`
+}
+
+// registry has every rule that the policy can configure.
+var registry = conventionalcommits.Registry
+
+// RuleDescriptors describes every rule, whether it's enabled or not, with its configured severity.
+func (p *Policy) RuleDescriptors() ([]linter.RuleDescriptor, error) {
+	descriptors := registry.Descriptors()
+
+	for i := range descriptors {
+		severity, err := p.severity(registry[i])
+		if err != nil {
+			return nil, err
+		}
+
+		descriptors[i].Severity = severity
+	}
+
+	return descriptors, nil
+}
+
+// allowedTypes returns the allowed types, which are the default types unless the allowed types are given, and the types
+// that bump versions. Next-version uses the same bumps, so that the types that it knows and the allowed types agree.
+func (p *Policy) allowedTypes() ([]string, error) {
+	bumps, err := parseBumps(p.Bumps)
+	if err != nil {
+		return nil, err
+	}
+
+	types := p.Types
+	if len(types) == 0 {
+		types = conventionalcommits.DefaultTypes
+	}
+
+	for _, typ := range bumps.Types() {
+		if !slices.Contains(types, typ) {
+			types = append(slices.Clip(types), typ)
+		}
+	}
+
+	return types, nil
+}
+
+// versionBumps returns the bumps that next-version uses: the default bumps, overridden by the configured bumps.
+func (p *Policy) versionBumps() (nextversion.Bumps, error) {
+	configured, err := parseBumps(p.Bumps)
+	if err != nil {
+		return nil, err
+	}
+
+	bumps := maps.Clone(nextversion.DefaultBumps)
+	maps.Copy(bumps, configured)
+
+	return bumps, nil
+}
+
+// parseBumps parses the configured bumps.
+func parseBumps(configured config.Bumps) (nextversion.Bumps, error) {
+	bumps := make(nextversion.Bumps, len(configured))
+
+	for key, name := range configured {
+		var bump nextversion.Bump
+
+		if err := bump.UnmarshalText([]byte(name)); err != nil {
+			return nil, fmt.Errorf("bumps: %q: %w", key, err)
+		}
+
+		bumps[key] = bump
+	}
+
+	if err := bumps.Validate(); err != nil {
+		return nil, fmt.Errorf("bumps: %w", err)
+	}
+
+	return bumps, nil
+}
+
+// allowedScopes returns the allowed scopes, which are the scopes that have paths unless the allowed scopes are given.
+func (p *Policy) allowedScopes() []string {
+	if len(p.Scopes) == 0 {
+		return slices.Sorted(maps.Keys(p.ScopePaths))
+	}
+
+	return p.Scopes
+}
+
+// enabled reports if the rule is enabled by the policy.
+func (p *Policy) enabled(def commitlinter.RuleDefinition) bool {
+	return p.Rules.Enabled(def.ID, !def.Disabled)
+}
+
+// severity returns the configured severity of the rule, or its default severity.
+func (p *Policy) severity(def commitlinter.RuleDefinition) (linter.Severity, error) {
+	severity := def.Severity
+
+	if name := p.Rules.Severity(def.ID); name != "" {
+		if err := severity.UnmarshalText([]byte(name)); err != nil {
+			return severity, fmt.Errorf("rule %q: %w", def.ID, err)
+		}
+	}
+
+	return severity, nil
+}
+
+// CommitLinter creates a commit linter with the rules that are enabled by the policy. Some rules use the repository,
+// e.g. its mailmap, which may be nil.
+func (p *Policy) CommitLinter(repo *git.Repository) (*commitlinter.Linter, error) {
+	for id := range p.Rules {
+		def, err := registry.Lookup(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if def.New == nil && !p.enabled(def) {
+			return nil, fmt.Errorf("rule %q can't be disabled, but its severity can be changed", id)
+		}
+	}
+
+	for _, id := range p.Suppressible {
+		if id == commitlinter.AnyRule {
+			continue
+		}
+
+		if _, err := registry.Lookup(id); err != nil {
+			return nil, fmt.Errorf("suppressible: %w", err)
+		}
+	}
+
+	types, err := p.allowedTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	var identities *mailmap.Mailmap
+
+	if repo != nil {
+		identities, err = mailmap.Load(repo)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	severities := make(map[string]linter.Severity)
+
+	var prechecks, rules commitlinter.Rules
+
+	for _, def := range registry {
+		if !p.enabled(def) {
+			continue
+		}
+
+		severity, err := p.severity(def)
+		if err != nil {
+			return nil, err
+		}
+
+		severities[def.ID] = severity
+
+		// The rule is enforced by the commit linter itself.
+		if def.New == nil {
+			continue
+		}
+
+		rule, err := def.New(commitlinter.RuleConfig{
+			Types:      types,
+			Scopes:     p.allowedScopes(),
+			ScopePaths: p.ScopePaths,
+			TypePaths:  p.TypePaths,
+			Mailmap:    identities,
+			Repository: repo,
+			DecodeOptions: func(v any) error {
+				return p.Rules.DecodeOptions(def.ID, v)
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot create rule %q: %w", def.ID, err)
+		}
+
+		if def.Precheck {
+			prechecks = append(prechecks, commitlinter.NamedRule(def.ID, rule))
+		} else {
+			rules = append(rules, commitlinter.NamedRule(def.ID, rule))
+		}
+	}
+
+	return &commitlinter.Linter{
+		Prechecks:    prechecks,
+		Rules:        rules,
+		Severities:   severities,
+		Suppressible: p.Suppressible,
+	}, nil
+}
@@ -0,0 +1,114 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/google/go-cmp/cmp"
+
+	"codeberg.org/somebadcode/commit-tool/cmd"
+	"codeberg.org/somebadcode/commit-tool/config"
+	"codeberg.org/somebadcode/commit-tool/linter"
+)
+
+func TestPolicy_CommitLinter(t *testing.T) {
+	t.Parallel()
+
+	// The default policy, with the default rules.
+	policy := cmd.Policy{Suppressible: []string{"*"}}
+
+	commitLinter, err := policy.CommitLinter(nil)
+	if err != nil {
+		t.Fatalf("CommitLinter() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		message   string
+		wantRules []string
+	}{
+		{
+			name:    "conventional",
+			message: "feat: add foo\n",
+		},
+		{
+			name:      "unconventional",
+			message:   "Feat: Add foo.\n",
+			wantRules: []string{"type-enum", "subject-case", "subject-full-stop"},
+		},
+		{
+			name:    "merge_branch",
+			message: "Merge branch 'topic'\n",
+		},
+		{
+			name:    "merge_branch_into",
+			message: "Merge branch 'topic' into main\n",
+		},
+		{
+			name:    "merge_pull_request",
+			message: "Merge pull request #42 from gopher/topic\n\nfeat: add foo\n",
+		},
+		{
+			name:    "revert",
+			message: "Revert \"feat: add foo\"\n\nThis reverts commit 8f1c0d6a2b5e4f3a9c7d1e0b2a4c6e8f0a1b3c5d.\n",
+		},
+		{
+			name:    "revert_of_revert",
+			message: "Revert \"Revert \"feat: add foo\"\"\n\nThis reverts commit 8f1c0d6a2b5e4f3a9c7d1e0b2a4c6e8f0a1b3c5d.\n",
+		},
+		{
+			name:    "reapply",
+			message: "Reapply \"feat: add foo\"\n\nThis reverts commit 8f1c0d6a2b5e4f3a9c7d1e0b2a4c6e8f0a1b3c5d.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotRules []string
+
+			for _, lintError := range linter.LintErrors(commitLinter.Lint(&object.Commit{Message: tt.message})) {
+				if lintError.Fails() {
+					gotRules = append(gotRules, lintError.Rule)
+				}
+			}
+
+			if diff := cmp.Diff(tt.wantRules, gotRules); diff != "" {
+				t.Errorf("Lint() failed rules mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPolicy_CommitLinter_bumps(t *testing.T) {
+	t.Parallel()
+
+	// The types that next-version bumps for are allowed.
+	policy := cmd.Policy{
+		Bumps:        config.Bumps{"api": "minor", "chore(deps)": "patch"},
+		Suppressible: []string{"*"},
+	}
+
+	commitLinter, err := policy.CommitLinter(nil)
+	if err != nil {
+		t.Fatalf("CommitLinter() error = %v", err)
+	}
+
+	for _, message := range []string{"api: add endpoint\n", "chore(deps): bump foo\n", "feat: add foo\n"} {
+		if err = commitLinter.Lint(&object.Commit{Message: message}); linter.Failed(err) {
+			t.Errorf("Lint() of %q error = %v", message, err)
+		}
+	}
+
+	if err = commitLinter.Lint(&object.Commit{Message: "wip: add foo\n"}); !linter.Failed(err) {
+		t.Errorf("Lint() of a type that is neither allowed nor bumped didn't fail")
+	}
+}
@@ -0,0 +1,89 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"codeberg.org/somebadcode/commit-tool/githooks"
+	"codeberg.org/somebadcode/commit-tool/linter"
+)
+
+// lintPushUpdates lints the commits of each ref update that git passes to the pre-push hook. The template is copied for
+// each ref update, which is reported as a range of its own. Commits that are reachable from the remote's current commit
+// or from a remote-tracking branch are not linted since they have already been pushed.
+func lintPushUpdates(ctx context.Context, l *slog.Logger, template linter.Linter, reporter linter.Reporter) error {
+	updates, err := githooks.ReadPushUpdates(os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	tips, err := linter.ReferencedCommits(template.Repo, func(ref *plumbing.Reference) bool {
+		return ref.Name().IsRemote()
+	})
+	if err != nil {
+		return err
+	}
+
+	// The history of the remote-tracking branches is only walked once, not for every ref update.
+	pushed, err := linter.Reachable(template.Repo, tips...)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, update := range updates {
+		if update.IsDelete() {
+			if l.Enabled(ctx, slog.LevelDebug) {
+				l.LogAttrs(ctx, slog.LevelDebug, "skipping deleted ref",
+					slog.String("ref", update.RemoteRef.String()),
+				)
+			}
+
+			continue
+		}
+
+		lint := template
+		lint.Rev = plumbing.Revision(update.LocalHash.String())
+		lint.OtherRev = ""
+		lint.Exclude = pushed
+
+		// The remote's commit is unknown if someone else has pushed to it since the last fetch.
+		if _, err = template.Repo.CommitObject(update.RemoteHash); err == nil {
+			lint.Exclude = maps.Clone(pushed)
+
+			if err = linter.AddReachable(template.Repo, lint.Exclude, update.RemoteHash); err != nil {
+				return err
+			}
+		}
+
+		if l.Enabled(ctx, slog.LevelDebug) {
+			l.LogAttrs(ctx, slog.LevelDebug, "linting ref update",
+				slog.String("local_ref", update.LocalRef.String()),
+				slog.String("remote_ref", update.RemoteRef.String()),
+				slog.String("revision", lint.Rev.String()),
+			)
+		}
+
+		reporter.BeginRange(update.LocalRef.String())
+
+		if err = lint.Run(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", update.LocalRef, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
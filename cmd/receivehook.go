@@ -0,0 +1,186 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"codeberg.org/somebadcode/commit-tool/githooks"
+	"codeberg.org/somebadcode/commit-tool/internal/objectdirs"
+	"codeberg.org/somebadcode/commit-tool/linter"
+)
+
+type ReceiveHookCommand struct {
+	Ref        string          `kong:"arg,optional,help='ref that is updated, when run as an update hook'"`
+	Old        string          `kong:"arg,optional,help='old object name of the ref, when run as an update hook'"`
+	New        string          `kong:"arg,optional,help='new object name of the ref, when run as an update hook'"`
+	Repository *git.Repository `kong:"placeholder='path',default='.',help='repository that is receiving the push'"`
+
+	Policy `kong:"embed,group='policy'"`
+}
+
+// Run lints the commits that a push adds to a repository. Without arguments, it reads the ref updates from stdin like
+// a pre-receive hook. With arguments, it lints a single ref update like an update hook. Commits that are reachable from
+// a branch or a tag in the repository are not linted since they have already been accepted.
+func (cmd *ReceiveHookCommand) Run(ctx context.Context, l *slog.Logger) error {
+	var updates []githooks.ReceiveUpdate
+
+	if cmd.Ref != "" {
+		update, err := githooks.ParseReceiveUpdate(cmd.Ref, cmd.Old, cmd.New)
+		if err != nil {
+			return err
+		}
+
+		updates = append(updates, update)
+	} else {
+		var err error
+
+		updates, err = githooks.ReadReceiveUpdates(os.Stdin)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The pushed objects are in quarantine until the hooks have accepted them.
+	repo, err := objectdirs.Open(cmd.Repository, objectdirs.FromEnvironment())
+	if err != nil {
+		return fmt.Errorf("cannot open object directories of repository: %w", err)
+	}
+
+	commitLinter, err := cmd.CommitLinter(repo)
+	if err != nil {
+		return err
+	}
+
+	tips, err := linter.ReferencedCommits(repo, func(ref *plumbing.Reference) bool {
+		return ref.Name().IsBranch() || ref.Name().IsTag()
+	})
+	if err != nil {
+		return err
+	}
+
+	// The history of the branches and tags is only walked once, not for every ref update.
+	accepted, err := linter.Reachable(repo, tips...)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, update := range updates {
+		if update.IsDelete() {
+			if l.Enabled(ctx, slog.LevelDebug) {
+				l.LogAttrs(ctx, slog.LevelDebug, "skipping deleted ref",
+					slog.String("ref", update.Ref.String()),
+				)
+			}
+
+			continue
+		}
+
+		tip, ok := receivedCommit(repo, update.New)
+		if !ok {
+			if l.Enabled(ctx, slog.LevelDebug) {
+				l.LogAttrs(ctx, slog.LevelDebug, "skipping ref that doesn't point at a commit",
+					slog.String("ref", update.Ref.String()),
+					slog.String("hash", update.New.String()),
+				)
+			}
+
+			continue
+		}
+
+		lint := linter.Linter{
+			Repo:         repo,
+			Rev:          plumbing.Revision(tip.String()),
+			ReportFunc:   rejectReporter(os.Stderr, update.Ref),
+			CommitLinter: commitLinter,
+			Exclude:      accepted,
+			Logger:       l,
+		}
+
+		if !update.IsCreate() {
+			lint.Exclude = maps.Clone(accepted)
+
+			if err = linter.AddReachable(repo, lint.Exclude, update.Old); err != nil {
+				return err
+			}
+		}
+
+		if l.Enabled(ctx, slog.LevelDebug) {
+			l.LogAttrs(ctx, slog.LevelDebug, "linting ref update",
+				slog.String("ref", update.Ref.String()),
+				slog.String("old", update.Old.String()),
+				slog.String("new", update.New.String()),
+			)
+		}
+
+		if err = lint.Run(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", update.Ref, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// receivedCommit returns the commit that a ref update points the ref at, peeling annotated tags.
+func receivedCommit(repo *git.Repository, hash plumbing.Hash) (plumbing.Hash, bool) {
+	if tag, err := repo.TagObject(hash); err == nil {
+		commit, err := tag.Commit()
+		if err != nil {
+			return plumbing.ZeroHash, false
+		}
+
+		return commit.Hash, true
+	}
+
+	if _, err := repo.CommitObject(hash); err != nil {
+		return plumbing.ZeroHash, false
+	}
+
+	return hash, true
+}
+
+// rejectReporter writes one line per violation, git shows them to the user that is pushing. Only errors reject the
+// push, other violations are written as warnings, infos and suppressed violations.
+func rejectReporter(w io.Writer, ref plumbing.ReferenceName) linter.ReportFunc {
+	return func(_ context.Context, err error) {
+		for _, lintError := range linter.LintErrors(err) {
+			prefix := "rejected " + ref.Short()
+
+			switch {
+			case lintError.Suppressed:
+				prefix = "suppressed " + ref.Short()
+			case lintError.Severity != linter.SeverityError:
+				prefix = lintError.Severity.String() + " " + ref.Short()
+			}
+
+			message := lintError.Err.Error()
+			if lintError.Suggestion != "" {
+				message += "; " + lintError.Suggestion
+			}
+
+			if lintError.Hash.IsZero() {
+				_, _ = fmt.Fprintf(w, "%s: %s\n", prefix, message)
+
+				continue
+			}
+
+			_, _ = fmt.Fprintf(w, "%s: commit %s: %s\n", prefix, lintError.Hash.String()[:linter.ShortHashLength], message)
+		}
+	}
+}
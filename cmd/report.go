@@ -0,0 +1,84 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/linter"
+)
+
+// newReporter returns the reporter of the report format. The text format is written to stderr, the other formats but
+// log are written to w. The auto format is text if stderr is a terminal and log otherwise. The rules are described in
+// the reports that support it.
+func newReporter(format string, w io.Writer, l *slog.Logger, rules []linter.RuleDescriptor) linter.Reporter {
+	if format == "auto" {
+		format = "log"
+
+		if isTerminal(os.Stderr) {
+			format = "text"
+		}
+	}
+
+	switch format {
+	case "text":
+		return linter.NewTextReporter(os.Stderr, rules, useColor(os.Stderr))
+	case "json":
+		return linter.NewJSONReporter(w)
+	case "sarif":
+		return linter.NewSARIFReporter(w, rules)
+	case "junit":
+		return linter.NewJUnitReporter(w)
+	case "tap":
+		return linter.NewTAPReporter(w)
+	}
+
+	return &logReporter{
+		report: linter.SlogReporter(l),
+	}
+}
+
+// useColor reports if output to the file should be colored. See https://no-color.org/.
+func useColor(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	return isTerminal(f)
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// logReporter logs the violations as they are found.
+type logReporter struct {
+	report linter.ReportFunc
+}
+
+func (r *logReporter) BeginRange(_ string) {}
+
+func (r *logReporter) Report(ctx context.Context, err error) {
+	r.report(ctx, err)
+}
+
+func (r *logReporter) Pass(_ context.Context, _ *object.Commit) {}
+
+func (r *logReporter) Finish() error {
+	return nil
+}
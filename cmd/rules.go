@@ -0,0 +1,138 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/go-git/go-git/v5"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+)
+
+type RulesCommand struct {
+	List    RulesListCommand    `kong:"cmd,help='list the rules with their configured state and severity'"`
+	Explain RulesExplainCommand `kong:"cmd,help='explain a rule'"`
+}
+
+type RulesListCommand struct {
+	Repository *git.Repository `kong:"placeholder='path',default='.',help='repository whose configuration is shown'"`
+
+	Policy `kong:"embed,group='policy'"`
+}
+
+func (cmd *RulesListCommand) Run(_ context.Context, _ *slog.Logger) error {
+	return cmd.list(os.Stdout)
+}
+
+func (cmd *RulesListCommand) list(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(tw, "ID\tSTATE\tSEVERITY\tTITLE")
+
+	for _, def := range registry {
+		severity, err := cmd.severity(def)
+		if err != nil {
+			return err
+		}
+
+		state := "enabled"
+		if !cmd.enabled(def) {
+			state = "disabled"
+		}
+
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", def.ID, state, severity, def.Title)
+	}
+
+	return tw.Flush()
+}
+
+type RulesExplainCommand struct {
+	ID string `kong:"arg,name='id',help='ID of the rule to explain'"`
+}
+
+func (cmd *RulesExplainCommand) Run(_ context.Context, _ *slog.Logger) error {
+	def, err := registry.Lookup(cmd.ID)
+	if err != nil {
+		return err
+	}
+
+	return explain(os.Stdout, def)
+}
+
+// explainWidth is the width that explanations are wrapped at.
+const explainWidth = 80
+
+// explain writes the definition of the rule, with its description and options, for humans.
+func explain(w io.Writer, def commitlinter.RuleDefinition) error {
+	var sb strings.Builder
+
+	_, _ = fmt.Fprintf(&sb, "%s: %s\n\n", def.ID, def.Title)
+	_, _ = fmt.Fprintf(&sb, "%s\n\n", wrap(def.Description, explainWidth))
+
+	if def.Help != "" {
+		_, _ = fmt.Fprintf(&sb, "%s\n\n", wrap("How to fix: "+def.Help, explainWidth))
+	}
+
+	defaultState := "enabled"
+	if def.Disabled {
+		defaultState = "disabled"
+	}
+
+	_, _ = fmt.Fprintf(&sb, "Default: %s, %s\n", defaultState, def.Severity)
+
+	if def.New == nil {
+		_, _ = fmt.Fprintf(&sb, "This rule can't be disabled.\n")
+	}
+
+	if len(def.Options) > 0 {
+		_, _ = fmt.Fprintf(&sb, "\nOptions:\n")
+
+		tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+
+		for _, option := range def.Options {
+			_, _ = fmt.Fprintf(tw, "  %s\t%s\t(default %s)\t%s\n", option.Name, option.Type, option.Default, option.Description)
+		}
+
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+// wrap wraps the text at spaces so that lines aren't wider than width, unless a single word is.
+func wrap(text string, width int) string {
+	var sb strings.Builder
+
+	lineLength := 0
+
+	for _, word := range strings.Fields(text) {
+		if lineLength > 0 && lineLength+1+len(word) > width {
+			sb.WriteByte('\n')
+			lineLength = 0
+		} else if lineLength > 0 {
+			sb.WriteByte(' ')
+			lineLength++
+		}
+
+		sb.WriteString(word)
+		lineLength += len(word)
+	}
+
+	return sb.String()
+}
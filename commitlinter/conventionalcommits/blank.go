@@ -0,0 +1,38 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package conventionalcommits
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+)
+
+// VerifyBodyLeadingBlank verifies that a blank line separates the body from the header.
+func VerifyBodyLeadingBlank(msg commitparser.CommitMessage, _ *object.Commit) error {
+	if msg.Body == "" || msg.Separation.BodyBlankLines > 0 {
+		return nil
+	}
+
+	return commitlinter.ErrorAt(msg.Separation.BodyPos,
+		fmt.Errorf("body must be separated from the header by a blank line: %w", commitlinter.ErrNoBlankLine))
+}
+
+// VerifyFooterLeadingBlank verifies that a blank line separates the footer from the body, or the header if there's no
+// body. Trailers at the end of the body that aren't separated from it are not parsed as trailers by git either.
+func VerifyFooterLeadingBlank(msg commitparser.CommitMessage, _ *object.Commit) error {
+	if msg.Separation.FooterPos == 0 || msg.Separation.FooterBlankLines > 0 {
+		return nil
+	}
+
+	return commitlinter.ErrorAt(msg.Separation.FooterPos,
+		fmt.Errorf("footer must be separated from the body by a blank line: %w", commitlinter.ErrNoBlankLine))
+}
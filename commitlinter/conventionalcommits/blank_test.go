@@ -0,0 +1,92 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package conventionalcommits_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitlinter/conventionalcommits"
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+)
+
+func TestLeadingBlankRules(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		rule    commitlinter.RuleFunc
+		message string
+		wantErr error
+		wantPos int
+	}{
+		{
+			name:    "body_separated",
+			rule:    conventionalcommits.VerifyBodyLeadingBlank,
+			message: "fix: bug\n\nThe body.\n",
+		},
+		{
+			name:    "body_not_separated",
+			rule:    conventionalcommits.VerifyBodyLeadingBlank,
+			message: "fix: bug\nThe body.\n",
+			wantErr: commitlinter.ErrNoBlankLine,
+			wantPos: 9,
+		},
+		{
+			name:    "no_body",
+			rule:    conventionalcommits.VerifyBodyLeadingBlank,
+			message: "fix: bug\n",
+		},
+		{
+			name:    "footer_separated",
+			rule:    conventionalcommits.VerifyFooterLeadingBlank,
+			message: "fix: bug\n\nThe body.\n\nRefs: ABC-123\n",
+		},
+		{
+			name:    "footer_without_body",
+			rule:    conventionalcommits.VerifyFooterLeadingBlank,
+			message: "fix: bug\n\nRefs: ABC-123\n",
+		},
+		{
+			name:    "footer_not_separated",
+			rule:    conventionalcommits.VerifyFooterLeadingBlank,
+			message: "fix: bug\n\nThe body.\nSigned-off-by: Gopher <gopher@example.com>\n",
+			wantErr: commitlinter.ErrNoBlankLine,
+			wantPos: 20,
+		},
+		{
+			name:    "trailer_like_body",
+			rule:    conventionalcommits.VerifyFooterLeadingBlank,
+			message: "fix: bug\n\nNote: this is the body.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			msg, err := commitparser.Parse(tt.message)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			err = tt.rule(msg, &object.Commit{Message: tt.message})
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("rule error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var posError commitlinter.PosError
+			if errors.As(err, &posError) && posError.Pos != tt.wantPos {
+				t.Errorf("rule error pos = %d, want %d", posError.Pos, tt.wantPos)
+			}
+		})
+	}
+}
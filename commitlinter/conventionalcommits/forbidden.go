@@ -0,0 +1,56 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package conventionalcommits
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+)
+
+// DefaultForbiddenPatterns match headers of work in progress, e.g. "WIP", "wip: foo" and "[WIP] foo".
+var DefaultForbiddenPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)^\W*wip\b`),
+}
+
+// ForbiddenPattern returns a rule that rejects commits that git rebase --autosquash squashes into earlier commits, like
+// "fixup! feat: add foo", and commits with headers that one of the patterns matches. It's meant as a precheck, see
+// [commitlinter.Linter.Prechecks], since such messages are often not conventional commits. Autosquash messages that
+// aren't committed yet are allowed, so that git commit --fixup can be used, but the patterns are still matched.
+func ForbiddenPattern(patterns []*regexp.Regexp) commitlinter.RuleFunc {
+	return func(msg commitparser.CommitMessage, commit *object.Commit) error {
+		// The header is taken from the commit, since the message may not have been parsed.
+		if commit == nil {
+			return nil
+		}
+
+		if msg.Autosquash != "" && !commit.Hash.IsZero() {
+			err := fmt.Errorf("%s! commit must be squashed before it's merged: %w", msg.Autosquash,
+				commitlinter.ErrForbiddenMessage)
+
+			return commitlinter.WithSuggestion(commitlinter.ErrorAt(0, err),
+				"squash it into the commit that it amends using git rebase --interactive --autosquash")
+		}
+
+		header, _, _ := strings.Cut(commit.Message, "\n")
+
+		for _, pattern := range patterns {
+			if loc := pattern.FindStringIndex(header); loc != nil {
+				return commitlinter.ErrorAt(loc[0], fmt.Errorf("header has %q, which a forbidden pattern matches: %w",
+					header[loc[0]:loc[1]], commitlinter.ErrForbiddenMessage))
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,115 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package conventionalcommits_test
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitlinter/conventionalcommits"
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+)
+
+func TestForbiddenPattern(t *testing.T) {
+	t.Parallel()
+
+	rule := conventionalcommits.ForbiddenPattern(slices.Concat(conventionalcommits.DefaultForbiddenPatterns,
+		[]*regexp.Regexp{regexp.MustCompile(`(?i)do not merge`)}))
+
+	hash := plumbing.NewHash("8f1c0d6a2b5e4f3a9c7d1e0b2a4c6e8f0a1b3c5d")
+
+	tests := []struct {
+		name    string
+		message string
+		hash    plumbing.Hash
+		wantErr error
+		wantPos int
+	}{
+		{
+			name:    "conventional",
+			message: "feat: add foo",
+			hash:    hash,
+		},
+		{
+			name:    "wipe",
+			message: "feat: wipe the cache",
+			hash:    hash,
+		},
+		{
+			name:    "wip",
+			message: "WIP",
+			hash:    hash,
+			wantErr: commitlinter.ErrForbiddenMessage,
+		},
+		{
+			name:    "wip_type",
+			message: "wip: add foo",
+			hash:    hash,
+			wantErr: commitlinter.ErrForbiddenMessage,
+		},
+		{
+			name:    "bracketed_wip",
+			message: "[WIP] add foo",
+			hash:    hash,
+			wantErr: commitlinter.ErrForbiddenMessage,
+		},
+		{
+			name:    "configured",
+			message: "feat: add foo (do not merge)",
+			hash:    hash,
+			wantErr: commitlinter.ErrForbiddenMessage,
+			wantPos: 15,
+		},
+		{
+			name:    "fixup",
+			message: "fixup! feat: add foo",
+			hash:    hash,
+			wantErr: commitlinter.ErrForbiddenMessage,
+		},
+		{
+			name:    "amend",
+			message: "amend! fix: handle empty input\n\nfix: handle empty and blank input\n",
+			hash:    hash,
+			wantErr: commitlinter.ErrForbiddenMessage,
+		},
+		{
+			name:    "not_committed",
+			message: "fixup! feat: add foo",
+		},
+		{
+			name:    "wip_not_committed",
+			message: "WIP",
+			wantErr: commitlinter.ErrForbiddenMessage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The message is incomplete if it can't be parsed, like it is for prechecks.
+			msg, _ := commitparser.Parse(tt.message)
+
+			err := rule(msg, &object.Commit{Hash: tt.hash, Message: tt.message})
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("rule error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var posError commitlinter.PosError
+			if errors.As(err, &posError); posError.Pos != tt.wantPos {
+				t.Errorf("rule error position = %d, want %d", posError.Pos, tt.wantPos)
+			}
+		})
+	}
+}
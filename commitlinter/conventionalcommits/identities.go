@@ -0,0 +1,116 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package conventionalcommits
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+	"codeberg.org/somebadcode/commit-tool/mailmap"
+)
+
+// IdentityTrailers returns a rule that verifies the trailers with identities, see [commitparser.IdentityTrailerKeys].
+// Each must be a name followed by an email address in angle brackets, and no identity may be given twice for the same
+// key. Identities are compared by email address, after the identities mailmap maps them to canonical ones, which may
+// be nil. If known isn't nil, every identity must be known to it.
+func IdentityTrailers(identities *mailmap.Mailmap, known func(commitparser.Identity) bool) commitlinter.RuleFunc {
+	return func(msg commitparser.CommitMessage, commit *object.Commit) error {
+		type trailer struct {
+			key   string
+			value string
+			pos   int
+		}
+
+		var trailers []trailer
+
+		for key, values := range msg.Trailers {
+			if _, ok := commitparser.IdentityTrailerKey(key); !ok {
+				continue
+			}
+
+			for _, value := range values {
+				trailers = append(trailers, trailer{
+					key:   key,
+					value: value,
+					pos:   commitlinter.TrailerPos(msg, commit, key, value),
+				})
+			}
+		}
+
+		// Trailers are verified in the order of the message, so that the later of two trailers is the duplicate.
+		slices.SortStableFunc(trailers, func(a, b trailer) int {
+			return cmp.Or(a.pos-b.pos, strings.Compare(a.key, b.key))
+		})
+
+		seen := make(map[string][]string)
+
+		for _, tr := range trailers {
+			id, err := commitparser.ParseIdentity(tr.value)
+			if err != nil {
+				return commitlinter.WithSuggestion(commitlinter.ErrorAt(tr.pos,
+					fmt.Errorf("%s trailer %q: %w", tr.key, tr.value, err)), `use the form "Name <email>"`)
+			}
+
+			key, _ := commitparser.IdentityTrailerKey(tr.key)
+
+			_, email := identities.Map(id.Name, id.Email)
+			email = strings.ToLower(email)
+
+			if slices.Contains(seen[key], email) {
+				return commitlinter.WithSuggestion(commitlinter.ErrorAt(tr.pos,
+					fmt.Errorf("%s trailer for %s is given more than once: %w", key, id, commitlinter.ErrDuplicateTrailer)),
+					"remove the duplicate trailer")
+			}
+
+			seen[key] = append(seen[key], email)
+
+			if known != nil && !known(id) {
+				return commitlinter.ErrorAt(tr.pos,
+					fmt.Errorf("%s trailer for %s: %w", key, id, commitlinter.ErrUnknownIdentity))
+			}
+		}
+
+		return nil
+	}
+}
+
+// ParseAllowlist parses a list of identities, one "Name <email>" per line. Blank lines and lines that start with # are
+// ignored.
+func ParseAllowlist(r io.Reader) ([]commitparser.Identity, error) {
+	var identities []commitparser.Identity
+
+	scanner := bufio.NewScanner(r)
+
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		id, err := commitparser.ParseIdentity(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+
+		identities = append(identities, id)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading allowlist: %w", err)
+	}
+
+	return identities, nil
+}
@@ -0,0 +1,124 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package conventionalcommits_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/google/go-cmp/cmp"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitlinter/conventionalcommits"
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+	"codeberg.org/somebadcode/commit-tool/mailmap"
+)
+
+func TestIdentityTrailers(t *testing.T) {
+	t.Parallel()
+
+	identities, err := mailmap.Parse(strings.NewReader("Jane Doe <jane@example.com> <jdoe@example.com>\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	known := func(id commitparser.Identity) bool {
+		return id.Email != "stranger@example.com"
+	}
+
+	tests := []struct {
+		name    string
+		rule    commitlinter.RuleFunc
+		message string
+		wantErr error
+		wantPos int
+	}{
+		{
+			name:    "valid",
+			rule:    conventionalcommits.IdentityTrailers(identities, nil),
+			message: "feat: add foo\n\nCo-authored-by: Jane Doe <jane@example.com>\nReviewed-by: Jane Doe <jane@example.com>\n",
+		},
+		{
+			name:    "other_trailers",
+			rule:    conventionalcommits.IdentityTrailers(identities, nil),
+			message: "feat: add foo\n\nRefs: ABC-123\n",
+		},
+		{
+			name:    "malformed",
+			rule:    conventionalcommits.IdentityTrailers(identities, nil),
+			message: "feat: add foo\n\nRefs: ABC-123\nCo-authored-by: jane@example.com\n",
+			wantErr: commitparser.ErrInvalidIdentity,
+			wantPos: 29,
+		},
+		{
+			name:    "duplicate",
+			rule:    conventionalcommits.IdentityTrailers(identities, nil),
+			message: "feat: add foo\n\nCo-authored-by: Jane Doe <jane@example.com>\nCo-Authored-By: Jane <JDoe@example.com>\n",
+			wantErr: commitlinter.ErrDuplicateTrailer,
+			wantPos: 59,
+		},
+		{
+			name:    "known",
+			rule:    conventionalcommits.IdentityTrailers(nil, known),
+			message: "feat: add foo\n\nCo-authored-by: Jane Doe <jane@example.com>\n",
+		},
+		{
+			name:    "unknown",
+			rule:    conventionalcommits.IdentityTrailers(nil, known),
+			message: "feat: add foo\n\nCo-authored-by: Jane Doe <jane@example.com>\nTested-by: Stranger <stranger@example.com>\n",
+			wantErr: commitlinter.ErrUnknownIdentity,
+			wantPos: 59,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			msg, err := commitparser.Parse(tt.message)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			err = tt.rule(msg, &object.Commit{Message: tt.message})
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("rule error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var posError commitlinter.PosError
+			if errors.As(err, &posError); posError.Pos != tt.wantPos {
+				t.Errorf("rule error position = %d, want %d", posError.Pos, tt.wantPos)
+			}
+		})
+	}
+}
+
+func TestParseAllowlist(t *testing.T) {
+	t.Parallel()
+
+	got, err := conventionalcommits.ParseAllowlist(strings.NewReader("# Maintainers.\nJane Doe <jane@example.com>\n\n" +
+		"Joe Bloggs <joe@example.com>\n"))
+	if err != nil {
+		t.Fatalf("ParseAllowlist() error = %v", err)
+	}
+
+	want := []commitparser.Identity{
+		{Name: "Jane Doe", Email: "jane@example.com"},
+		{Name: "Joe Bloggs", Email: "joe@example.com"},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ParseAllowlist() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err = conventionalcommits.ParseAllowlist(strings.NewReader("Jane Doe <jane@example.com>\njoe\n")); !errors.Is(err, commitparser.ErrInvalidIdentity) {
+		t.Errorf("ParseAllowlist() error = %v, wantErr %v", err, commitparser.ErrInvalidIdentity)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package conventionalcommits
+
+import (
+	_ "embed"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+)
+
+//go:embed verbs.txt
+var verbsFile string
+
+// DefaultVerbs are the verbs that commit subjects commonly start with.
+var DefaultVerbs = ParseVerbs(strings.Split(verbsFile, "\n"))
+
+// Verbs maps the past tense, past participle, third person and gerund forms of verbs, e.g. "added", "adds" and
+// "adding", to the imperative, e.g. "add".
+type Verbs map[string]string
+
+// ParseVerbs parses lines of verbs, each an imperative followed by its other forms separated by white space, e.g.
+// "add added adds adding". Blank lines and lines that start with # are ignored. Verbs are lower case.
+func ParseVerbs(lines []string) Verbs {
+	verbs := make(Verbs)
+
+	for _, line := range lines {
+		fields := strings.Fields(strings.ToLower(line))
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		for _, form := range fields[1:] {
+			verbs[form] = fields[0]
+		}
+	}
+
+	return verbs
+}
+
+// ImperativeMood returns a rule that verifies that the subject doesn't start with one of the forms of the verbs, e.g.
+// "added foo" rather than "add foo". Words to ignore are matched ignoring case. Reverts and merge commits are not
+// checked since their subjects quote other commits.
+func ImperativeMood(verbs Verbs, ignore []string) commitlinter.RuleFunc {
+	return func(msg commitparser.CommitMessage, commit *object.Commit) error {
+		if msg.Revert || msg.Merge {
+			return nil
+		}
+
+		first, rest, _ := strings.Cut(msg.Subject, " ")
+		word := strings.ToLower(strings.TrimRight(first, ",.:;!?"))
+
+		imperative, found := verbs[word]
+		if !found || slices.ContainsFunc(ignore, func(w string) bool { return strings.EqualFold(w, word) }) {
+			return nil
+		}
+
+		// Keep the case of the subject, the subject-case rule checks it.
+		if r, _ := utf8.DecodeRuneInString(first); unicode.IsUpper(r) {
+			r, size := utf8.DecodeRuneInString(imperative)
+			imperative = string(unicode.ToUpper(r)) + imperative[size:]
+		}
+
+		subject := imperative
+		if rest != "" {
+			subject += " " + rest
+		}
+
+		err := fmt.Errorf("subject starts with %q rather than the imperative %q: %w", first, imperative,
+			commitlinter.ErrNotImperative)
+
+		return commitlinter.WithSuggestion(commitlinter.ErrorAt(commitlinter.SubjectPos(msg, commit), err),
+			fmt.Sprintf("did you mean %q?", subject))
+	}
+}
@@ -0,0 +1,126 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package conventionalcommits_test
+
+import (
+	"errors"
+	"maps"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitlinter/conventionalcommits"
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+)
+
+func TestImperativeMood(t *testing.T) {
+	t.Parallel()
+
+	verbs := maps.Clone(conventionalcommits.DefaultVerbs)
+	maps.Copy(verbs, conventionalcommits.ParseVerbs([]string{"dockerize dockerized dockerizes dockerizing"}))
+
+	rule := conventionalcommits.ImperativeMood(verbs, []string{"changes"})
+
+	tests := []struct {
+		name           string
+		message        string
+		wantErr        error
+		wantPos        int
+		wantSuggestion string
+	}{
+		{
+			name:    "imperative",
+			message: "feat: add foo",
+		},
+		{
+			name:           "past_tense",
+			message:        "feat: added foo",
+			wantErr:        commitlinter.ErrNotImperative,
+			wantPos:        6,
+			wantSuggestion: `did you mean "add foo"?`,
+		},
+		{
+			name:           "third_person",
+			message:        "fix(parser): fixes empty input",
+			wantErr:        commitlinter.ErrNotImperative,
+			wantPos:        13,
+			wantSuggestion: `did you mean "fix empty input"?`,
+		},
+		{
+			name:           "gerund",
+			message:        "refactor: Simplifying the parser",
+			wantErr:        commitlinter.ErrNotImperative,
+			wantPos:        10,
+			wantSuggestion: `did you mean "Simplify the parser"?`,
+		},
+		{
+			name:           "irregular",
+			message:        "docs: rewritten",
+			wantErr:        commitlinter.ErrNotImperative,
+			wantPos:        6,
+			wantSuggestion: `did you mean "rewrite"?`,
+		},
+		{
+			name:           "extended",
+			message:        "build: dockerized the service",
+			wantErr:        commitlinter.ErrNotImperative,
+			wantPos:        7,
+			wantSuggestion: `did you mean "dockerize the service"?`,
+		},
+		{
+			name:    "ignored",
+			message: "docs: changes to the readme",
+		},
+		{
+			name:    "unknown_word",
+			message: "chore: dependencies",
+		},
+		{
+			name:    "revert",
+			message: "revert: added foo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			msg, err := commitparser.Parse(tt.message)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			err = rule(msg, &object.Commit{Message: tt.message})
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("rule error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var posError commitlinter.PosError
+			if errors.As(err, &posError); posError.Pos != tt.wantPos {
+				t.Errorf("rule error position = %d, want %d", posError.Pos, tt.wantPos)
+			}
+
+			var suggestionError commitlinter.SuggestionError
+			if errors.As(err, &suggestionError); suggestionError.Suggestion != tt.wantSuggestion {
+				t.Errorf("rule suggestion = %q, want %q", suggestionError.Suggestion, tt.wantSuggestion)
+			}
+		})
+	}
+}
+
+func TestDefaultVerbs(t *testing.T) {
+	t.Parallel()
+
+	// Imperatives mustn't be forms of other verbs, or subjects in the imperative mood would be reported.
+	for form, imperative := range conventionalcommits.DefaultVerbs {
+		if other, found := conventionalcommits.DefaultVerbs[imperative]; found {
+			t.Errorf("imperative %q of %q is a form of %q", imperative, form, other)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package conventionalcommits
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+	"codeberg.org/somebadcode/commit-tool/internal/textwidth"
+)
+
+var (
+	urlPattern = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://\S`)
+
+	// trailerPattern matches the first line of a trailer, as opposed to the continuation lines of its value.
+	trailerPattern = regexp.MustCompile(`^(?:[A-Z][A-Za-z0-9-]*|BREAKING CHANGE)(?:: | #)`)
+)
+
+// HeaderMaxLength returns a rule that limits the width of the header to a number of columns.
+func HeaderMaxLength(limit int) commitlinter.RuleFunc {
+	return func(msg commitparser.CommitMessage, commit *object.Commit) error {
+		return maxWidth(commitlinter.HeaderLine(msg, commit), "header", limit)
+	}
+}
+
+// HeaderMinLength returns a rule that requires the header to be at least a number of columns wide.
+func HeaderMinLength(limit int) commitlinter.RuleFunc {
+	return func(msg commitparser.CommitMessage, commit *object.Commit) error {
+		header := commitlinter.HeaderLine(msg, commit)
+
+		if width := textwidth.Width(header.Text); width < limit {
+			return commitlinter.ErrorAt(header.Pos+len(header.Text),
+				fmt.Errorf("header is %d columns wide, the minimum is %d: %w", width, limit, commitlinter.ErrHeaderTooShort))
+		}
+
+		return nil
+	}
+}
+
+// BodyMaxLineLength returns a rule that limits the width of each line of the body to a number of columns. Lines with
+// URLs are exempt since URLs can't be wrapped.
+func BodyMaxLineLength(limit int) commitlinter.RuleFunc {
+	return func(msg commitparser.CommitMessage, commit *object.Commit) error {
+		for _, line := range commitlinter.BodyLines(msg, commit) {
+			if urlPattern.MatchString(line.Text) {
+				continue
+			}
+
+			if err := maxWidth(line, "body line", limit); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// FooterMaxLineLength returns a rule that limits the width of each line of the trailers to a number of columns. Lines
+// with URLs are exempt, and so is the first line of each trailer, however long its value is. The lines that a value is
+// continued on aren't.
+func FooterMaxLineLength(limit int) commitlinter.RuleFunc {
+	return func(msg commitparser.CommitMessage, commit *object.Commit) error {
+		for _, line := range commitlinter.FooterLines(msg, commit) {
+			if urlPattern.MatchString(line.Text) {
+				continue
+			}
+
+			if trailerPattern.MatchString(line.Text) {
+				continue
+			}
+
+			if err := maxWidth(line, "footer line", limit); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// maxWidth returns an error at the first character that goes past the limit if the line is too wide.
+func maxWidth(line commitlinter.Line, name string, limit int) error {
+	width := textwidth.Width(line.Text)
+	if width <= limit {
+		return nil
+	}
+
+	return commitlinter.ErrorAt(line.Pos+textwidth.Truncate(line.Text, limit),
+		fmt.Errorf("%s is %d columns wide, the limit is %d: %w", name, width, limit, commitlinter.ErrLineTooLong))
+}
@@ -0,0 +1,120 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package conventionalcommits_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitlinter/conventionalcommits"
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+)
+
+func TestLengthRules(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		rule    commitlinter.RuleFunc
+		message string
+		wantErr error
+		wantPos int
+	}{
+		{
+			name:    "header_fits",
+			rule:    conventionalcommits.HeaderMaxLength(20),
+			message: "feat: add 20 columns",
+		},
+		{
+			name:    "header_too_long",
+			rule:    conventionalcommits.HeaderMaxLength(20),
+			message: "feat: add 21 columns!",
+			wantErr: commitlinter.ErrLineTooLong,
+			wantPos: 20,
+		},
+		{
+			name:    "header_cjk_too_long",
+			rule:    conventionalcommits.HeaderMaxLength(20),
+			message: "feat: 日本語のサポート",
+			wantErr: commitlinter.ErrLineTooLong,
+			wantPos: 27,
+		},
+		{
+			name:    "header_emoji_fits",
+			rule:    conventionalcommits.HeaderMaxLength(20),
+			message: "feat: add 🎉 to logs",
+		},
+		{
+			name:    "header_too_short",
+			rule:    conventionalcommits.HeaderMinLength(10),
+			message: "fix: bug",
+			wantErr: commitlinter.ErrHeaderTooShort,
+			wantPos: 8,
+		},
+		{
+			name:    "body_line_too_long",
+			rule:    conventionalcommits.BodyMaxLineLength(20),
+			message: "fix: bug\n\nThe first line fits.\nThe second line doesn't.\n",
+			wantErr: commitlinter.ErrLineTooLong,
+			wantPos: 51,
+		},
+		{
+			name:    "body_url_exempt",
+			rule:    conventionalcommits.BodyMaxLineLength(20),
+			message: "fix: bug\n\nSee https://example.com/a/very/long/path/to/the/issue\n",
+		},
+		{
+			name:    "body_line_too_long_before_trailers",
+			rule:    conventionalcommits.BodyMaxLineLength(20),
+			message: "fix: bug\n\nThis line is too long for the body.\n\nRefs: ABC-123\n",
+			wantErr: commitlinter.ErrLineTooLong,
+			wantPos: 30,
+		},
+		{
+			name:    "footer_exempt",
+			rule:    conventionalcommits.FooterMaxLineLength(20),
+			message: "fix: bug\n\nSigned-off-by: Gopher With A Long Name <gopher@example.com>\nRefs: ABC-123,DEF-456,GHI-789\n",
+		},
+		{
+			name:    "footer_long_value",
+			rule:    conventionalcommits.FooterMaxLineLength(20),
+			message: "fix: bug\n\nBody.\n\nBREAKING CHANGE: the options are gone\n",
+		},
+		{
+			name:    "footer_continuation_too_long",
+			rule:    conventionalcommits.FooterMaxLineLength(20),
+			message: "fix: bug\n\nBody.\n\nBREAKING CHANGE: the options\n are gone and so is the config\n",
+			wantErr: commitlinter.ErrLineTooLong,
+			wantPos: 66,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			msg, err := commitparser.Parse(tt.message)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			err = tt.rule(msg, &object.Commit{Message: tt.message})
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("rule error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var posError commitlinter.PosError
+			if errors.As(err, &posError) && posError.Pos != tt.wantPos {
+				t.Errorf("rule error pos = %d, want %d", posError.Pos, tt.wantPos)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package conventionalcommits
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+)
+
+var (
+	// DefaultReferenceTypes are the types of commits that must reference an issue by default.
+	DefaultReferenceTypes = []string{"feat", "fix"}
+	// DefaultReferenceLocations are where references are looked for by default, the subject, the body and trailers.
+	DefaultReferenceLocations = []string{
+		commitparser.LocationSubject, commitparser.LocationBody, "Refs", "Closes", "Fixes", "Resolves",
+	}
+)
+
+// IssueReference returns a rule that requires commits of the types, or of every type if there are none, to reference
+// an issue that one of the patterns matches at one of the locations, see [commitparser.CommitMessage.References].
+// Merge commits are not checked.
+func IssueReference(types []string, patterns []*regexp.Regexp, locations []string) commitlinter.RuleFunc {
+	var trailer string
+
+	for _, location := range locations {
+		if location != commitparser.LocationSubject && location != commitparser.LocationBody {
+			trailer = location
+
+			break
+		}
+	}
+
+	return func(msg commitparser.CommitMessage, _ *object.Commit) error {
+		if msg.Merge || (len(types) > 0 && !slices.Contains(types, msg.Type)) {
+			return nil
+		}
+
+		if len(msg.References(patterns, locations...)) > 0 {
+			return nil
+		}
+
+		err := commitlinter.ErrorAt(0, fmt.Errorf("commit of type %q must reference an issue in %s: %w", msg.Type,
+			describeLocations(locations), commitlinter.ErrNoReference))
+
+		if trailer == "" {
+			return err
+		}
+
+		return commitlinter.WithSuggestion(err, fmt.Sprintf("add the trailer %q", trailer+": <issue>"))
+	}
+}
+
+// describeLocations describes the locations of references, e.g. "the body or a Refs or Closes trailer".
+func describeLocations(locations []string) string {
+	if len(locations) == 0 {
+		return "the message"
+	}
+
+	var places, trailers []string
+
+	for _, location := range locations {
+		switch location {
+		case commitparser.LocationSubject, commitparser.LocationBody:
+			places = append(places, "the "+location)
+		default:
+			trailers = append(trailers, location)
+		}
+	}
+
+	if len(trailers) > 0 {
+		places = append(places, "a "+orList(trailers)+" trailer")
+	}
+
+	return orList(places)
+}
+
+// orList joins the words, e.g. "a, b or c".
+func orList(words []string) string {
+	if len(words) < 2 {
+		return strings.Join(words, "")
+	}
+
+	return strings.Join(words[:len(words)-1], ", ") + " or " + words[len(words)-1]
+}
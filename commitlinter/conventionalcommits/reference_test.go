@@ -0,0 +1,114 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package conventionalcommits_test
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"testing"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitlinter/conventionalcommits"
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+)
+
+func TestIssueReference(t *testing.T) {
+	t.Parallel()
+
+	rule := conventionalcommits.IssueReference(conventionalcommits.DefaultReferenceTypes,
+		append(slices.Clone(commitparser.DefaultReferencePatterns), commitparser.TicketPattern("ABC")),
+		conventionalcommits.DefaultReferenceLocations)
+
+	tests := []struct {
+		name           string
+		rule           commitlinter.RuleFunc
+		message        string
+		wantErr        error
+		wantSuggestion string
+	}{
+		{
+			name:    "trailer",
+			rule:    rule,
+			message: "feat: add foo\n\nRefs: ABC-123\n",
+		},
+		{
+			name:    "body",
+			rule:    rule,
+			message: "fix: handle empty input\n\nAs reported in #123.\n",
+		},
+		{
+			name:           "no_reference",
+			rule:           rule,
+			message:        "feat: add foo\n\nFoo is needed.\n",
+			wantErr:        commitlinter.ErrNoReference,
+			wantSuggestion: `add the trailer "Refs: <issue>"`,
+		},
+		{
+			name:           "other_trailer",
+			rule:           rule,
+			message:        "fix: handle empty input\n\nSee-also: ABC-123\n",
+			wantErr:        commitlinter.ErrNoReference,
+			wantSuggestion: `add the trailer "Refs: <issue>"`,
+		},
+		{
+			name:           "not_a_ticket",
+			rule:           rule,
+			message:        "fix: decode the input as UTF-8\n\nThe checksums are SHA-256.\n\nRefs: ISO-8601\n",
+			wantErr:        commitlinter.ErrNoReference,
+			wantSuggestion: `add the trailer "Refs: <issue>"`,
+		},
+		{
+			name: "default_patterns",
+			rule: conventionalcommits.IssueReference(nil, commitparser.DefaultReferencePatterns,
+				conventionalcommits.DefaultReferenceLocations),
+			message:        "docs: explain foo\n\nRefs: ABC-123\n",
+			wantErr:        commitlinter.ErrNoReference,
+			wantSuggestion: `add the trailer "Refs: <issue>"`,
+		},
+		{
+			name:    "other_type",
+			rule:    rule,
+			message: "docs: explain foo\n",
+		},
+		{
+			name: "every_type",
+			rule: conventionalcommits.IssueReference(nil, commitparser.DefaultReferencePatterns,
+				[]string{commitparser.LocationSubject}),
+			message: "docs: explain foo\n\nRefs: ABC-123\n",
+			wantErr: commitlinter.ErrNoReference,
+		},
+		{
+			name: "pattern",
+			rule: conventionalcommits.IssueReference(nil, []*regexp.Regexp{regexp.MustCompile(`\bGH-[0-9]+\b`)},
+				[]string{"Refs"}),
+			message: "docs: explain foo\n\nRefs: GH-7\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			msg, err := commitparser.Parse(tt.message)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			err = tt.rule(msg, nil)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("rule error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var suggestionError commitlinter.SuggestionError
+			if errors.As(err, &suggestionError); suggestionError.Suggestion != tt.wantSuggestion {
+				t.Errorf("rule suggestion = %q, want %q", suggestionError.Suggestion, tt.wantSuggestion)
+			}
+		})
+	}
+}
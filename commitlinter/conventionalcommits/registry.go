@@ -0,0 +1,579 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package conventionalcommits
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+	"codeberg.org/somebadcode/commit-tool/internal/repofile"
+	"codeberg.org/somebadcode/commit-tool/linter"
+)
+
+// Rule IDs of the rules in [Registry].
+const (
+	RuleForbiddenPattern = "forbidden-pattern"
+	RuleTypeEnum         = "type-enum"
+	RuleTypePath         = "type-path"
+	RuleScopeEnum        = "scope-enum"
+	RuleScopePath        = "scope-path"
+	RuleSubjectEmpty     = "subject-empty"
+	RuleSubjectCase      = "subject-case"
+	RuleSubjectFullStop  = "subject-full-stop"
+	RuleImperativeMood   = "imperative-mood"
+
+	RuleHeaderMaxLength     = "header-max-length"
+	RuleHeaderMinLength     = "header-min-length"
+	RuleBodyMaxLineLength   = "body-max-line-length"
+	RuleFooterMaxLineLength = "footer-max-line-length"
+
+	RuleBodyLeadingBlank   = "body-leading-blank"
+	RuleFooterLeadingBlank = "footer-leading-blank"
+
+	RuleSignedOffBy      = "signed-off-by"
+	RuleIdentityTrailers = "identity-trailers"
+	RuleIssueReference   = "issue-reference"
+	RuleRevertedCommit   = "reverted-commit"
+)
+
+const (
+	// DefaultMaxLength is the default number of columns that the header and lines of the body and footer are limited to.
+	DefaultMaxLength = 100
+	// DefaultMinLength is the default minimum number of columns of the header.
+	DefaultMinLength = 10
+)
+
+type scopeEnumOptions struct {
+	Required bool `json:"required"`
+}
+
+type signedOffByOptions struct {
+	Signers []string `json:"signers"`
+	Mailmap bool     `json:"mailmap"`
+}
+
+type identityTrailersOptions struct {
+	Mailmap   bool   `json:"mailmap"`
+	Allowlist string `json:"allowlist"`
+}
+
+type issueReferenceOptions struct {
+	Types     []string `json:"types"`
+	Patterns  []string `json:"patterns"`
+	Projects  []string `json:"projects"`
+	Locations []string `json:"locations"`
+}
+
+type imperativeMoodOptions struct {
+	Verbs  []string `json:"verbs"`
+	Ignore []string `json:"ignore"`
+}
+
+type forbiddenPatternOptions struct {
+	Patterns []string `json:"patterns"`
+}
+
+type maxLengthOptions struct {
+	Max int `json:"max"`
+}
+
+type minLengthOptions struct {
+	Min int `json:"min"`
+}
+
+// Registry defines the Conventional Commits rules, starting with [commitlinter.HeaderFormat].
+var Registry = commitlinter.Registry{
+	commitlinter.HeaderFormat,
+	{
+		ID:    RuleForbiddenPattern,
+		Title: "No unfinished commits",
+		Description: "Commits that git rebase --autosquash squashes into earlier commits, with headers like " +
+			"\"fixup! feat: add foo\", \"squash! ...\" and \"amend! ...\", and work in progress, with headers like " +
+			"\"WIP\", must not be merged. Headers are matched by regular expressions, which can be configured. The " +
+			"rule is checked before the header is parsed, and a commit that fails it isn't checked further. Autosquash " +
+			"messages that aren't committed yet are allowed, so that git commit --fixup still works.",
+		Help:     "Squash the commit into the commit that it amends, or finish the work and reword the commit.",
+		Precheck: true,
+		Options: []commitlinter.OptionDefinition{
+			{
+				Name:        "patterns",
+				Type:        "[]string",
+				Default:     patternList(DefaultForbiddenPatterns),
+				Description: "regular expressions of forbidden headers",
+			},
+		},
+		New: func(config commitlinter.RuleConfig) (commitlinter.RuleFunc, error) {
+			var opts forbiddenPatternOptions
+			if err := config.Decode(&opts); err != nil {
+				return nil, err
+			}
+
+			patterns, err := compilePatterns(opts.Patterns, DefaultForbiddenPatterns)
+			if err != nil {
+				return nil, err
+			}
+
+			return ForbiddenPattern(patterns), nil
+		},
+	},
+	{
+		ID:    RuleTypeEnum,
+		Title: "Allowed types",
+		Description: "The type of the header must be one of the allowed types. The allowed types are set by the " +
+			"policy's types setting, it defaults to the types of the Angular convention: " +
+			"build, chore, ci, docs, feat, fix, perf, refactor, revert, style and test. The types that the bumps " +
+			"setting maps to version bumps are allowed too.",
+		Help: "Use one of the types that are allowed by the policy, see the types setting.",
+		New: func(config commitlinter.RuleConfig) (commitlinter.RuleFunc, error) {
+			types := config.Types
+			if len(types) == 0 {
+				types = DefaultTypes
+			}
+
+			return TypeEnum(types...), nil
+		},
+	},
+	{
+		ID:    RuleTypePath,
+		Title: "Type matches changed paths",
+		Description: "The type of a commit should match the files that it changes. The policy's type-paths setting " +
+			"maps types to gitignore-style patterns, e.g. docs to docs/** and *.md. If every file that a commit changes " +
+			"is covered by a single type, the commit is expected to have that type. A different type is only a " +
+			"warning by default, since e.g. a feature may be documentation only. The files of a message that isn't " +
+			"committed yet, like in the commit-msg hook, are the files that are staged. Reverts and merge commits " +
+			"are not checked.",
+		Help:     "Use the suggested type, or suppress the rule if the type is right after all.",
+		Severity: linter.SeverityWarning,
+		New: func(config commitlinter.RuleConfig) (commitlinter.RuleFunc, error) {
+			return TypePath(config.TypePaths, config.ScopePaths, config.Repository), nil
+		},
+	},
+	{
+		ID:    RuleScopeEnum,
+		Title: "Allowed scopes",
+		Description: "The scope of the header must be one of the allowed scopes. The allowed scopes are set by the " +
+			"policy's scopes setting, or the scopes of the scope-paths setting, any scope is allowed if both are " +
+			"empty. The scope must not start or end with white space, and it can be made mandatory.",
+		Help: "Use one of the scopes that are allowed by the policy, see the scopes setting. The scope may be required.",
+		Options: []commitlinter.OptionDefinition{
+			{
+				Name:        "required",
+				Type:        "bool",
+				Default:     "false",
+				Description: "reject commit messages without a scope",
+			},
+		},
+		New: func(config commitlinter.RuleConfig) (commitlinter.RuleFunc, error) {
+			var opts scopeEnumOptions
+			if err := config.Decode(&opts); err != nil {
+				return nil, err
+			}
+
+			return ScopeEnum(opts.Required, config.Scopes...), nil
+		},
+	},
+	{
+		ID:    RuleScopePath,
+		Title: "Scope matches changed paths",
+		Description: "The files that a commit changes must match its scope. The policy's scope-paths setting maps " +
+			"scopes to gitignore-style patterns, e.g. api to services/api/**. A commit must change at least one file " +
+			"covered by its scope, and no files that are only covered by other scopes. Files that no scope covers can " +
+			"be changed by any commit. The files of a message that isn't committed yet, like in the commit-msg " +
+			"hook, are the files that are staged. Commits with a scope that isn't mapped and merge commits are not " +
+			"checked.",
+		Help: "Use the scope of the files that the commit changes, or split the commit into one commit per scope.",
+		New: func(config commitlinter.RuleConfig) (commitlinter.RuleFunc, error) {
+			return ScopePath(config.ScopePaths, config.Repository), nil
+		},
+	},
+	{
+		ID:    RuleSubjectEmpty,
+		Title: "Subject present",
+		Description: "The header must have a subject after the colon and the space that follows it, and the subject " +
+			"must not start with more white space.",
+		Help: "Write a subject after the colon and the space that follows it.",
+		New:  staticRule(VerifySubjectEmpty),
+	},
+	{
+		ID:    RuleSubjectCase,
+		Title: "Lower case subject",
+		Description: "The subject must not start with an upper case letter. The subject continues the header, " +
+			"e.g. \"fix(parser): handle empty input\", so it's written like the rest of a sentence.",
+		Help: "Start the subject with a lower case letter, e.g. \"add foo\" rather than \"Add foo\".",
+		New:  staticRule(VerifySubjectCase),
+	},
+	{
+		ID:    RuleSubjectFullStop,
+		Title: "No trailing punctuation",
+		Description: "The subject must not end with a full stop or other punctuation. The header is a title, and " +
+			"punctuation only takes up space in the limited width of one-line logs.",
+		Help: "Remove the full stop or other punctuation at the end of the subject.",
+		New:  staticRule(VerifySubjectFullStop),
+	},
+	{
+		ID:    RuleImperativeMood,
+		Title: "Imperative subject",
+		Description: "The subject must be in the imperative mood, like \"add foo\" rather than \"added foo\", " +
+			"\"adds foo\" or \"adding foo\". The subject tells what applying the commit does. The first word is " +
+			"looked up in a built-in list of verbs, which a repository can extend with verbs of its own. Reverts and " +
+			"merge commits are not checked. The rule only warns by default since a word can be both a verb and a noun.",
+		Help:     "Start the subject with the suggested verb, or ignore the word if it isn't a verb.",
+		Severity: linter.SeverityWarning,
+		Options: []commitlinter.OptionDefinition{
+			{
+				Name:        "verbs",
+				Type:        "[]string",
+				Default:     "none",
+				Description: "more verbs, each an imperative followed by its other forms, e.g. \"dockerize dockerized dockerizes\"",
+			},
+			{
+				Name:        "ignore",
+				Type:        "[]string",
+				Default:     "none",
+				Description: "words that subjects may start with, e.g. \"changes\" as a noun",
+			},
+		},
+		New: func(config commitlinter.RuleConfig) (commitlinter.RuleFunc, error) {
+			var opts imperativeMoodOptions
+			if err := config.Decode(&opts); err != nil {
+				return nil, err
+			}
+
+			verbs := maps.Clone(DefaultVerbs)
+			maps.Copy(verbs, ParseVerbs(opts.Verbs))
+
+			return ImperativeMood(verbs, opts.Ignore), nil
+		},
+	},
+	{
+		ID:    RuleBodyLeadingBlank,
+		Title: "Blank line before body",
+		Description: "A blank line should separate the body from the header. Without it, git log --oneline and " +
+			"other tools take the first line of the body to be part of the subject. It's a warning by default, as " +
+			"in commitlint's conventional configuration.",
+		Help:     "Insert a blank line after the header.",
+		Severity: linter.SeverityWarning,
+		New:      staticRule(VerifyBodyLeadingBlank),
+	},
+	{
+		ID:    RuleFooterLeadingBlank,
+		Title: "Blank line before footer",
+		Description: "A blank line should separate the trailers from the body. Trailers like Signed-off-by that " +
+			"directly follow the body are part of it, and git and this tool don't read them as trailers. It's a " +
+			"warning by default, as in commitlint's conventional configuration.",
+		Help:     "Insert a blank line before the trailers.",
+		Severity: linter.SeverityWarning,
+		New:      staticRule(VerifyFooterLeadingBlank),
+	},
+	{
+		ID:    RuleSignedOffBy,
+		Title: "Signed off by the author",
+		Description: "The commit message must have a Signed-off-by trailer by the author of the commit, which " +
+			"certifies the Developer Certificate of Origin. Identities are matched by email address, after the " +
+			"repository's .mailmap maps them to canonical ones. Other signers, e.g. a bot that commits on behalf of " +
+			"others, can be allowed. The rule is disabled by default.",
+		Help:     "Add the trailer, git commit --signoff adds it when the author is also the committer.",
+		Disabled: true,
+		Options: []commitlinter.OptionDefinition{
+			{
+				Name:        "signers",
+				Type:        "[]string",
+				Default:     "none",
+				Description: "identities that may sign off any commit, as \"Name <email>\" or an email address",
+			},
+			{
+				Name:        "mailmap",
+				Type:        "bool",
+				Default:     "true",
+				Description: "map identities to canonical ones using the repository's .mailmap",
+			},
+		},
+		New: func(config commitlinter.RuleConfig) (commitlinter.RuleFunc, error) {
+			opts := signedOffByOptions{Mailmap: true}
+			if err := config.Decode(&opts); err != nil {
+				return nil, err
+			}
+
+			if !opts.Mailmap {
+				config.Mailmap = nil
+			}
+
+			return SignedOffBy(opts.Signers, config.Mailmap), nil
+		},
+	},
+	{
+		ID:    RuleIdentityTrailers,
+		Title: "Well-formed identity trailers",
+		Description: "Trailers with identities, Signed-off-by, Co-authored-by, Reviewed-by, Acked-by, Tested-by, " +
+			"Reported-by and Suggested-by, must have a name followed by an email address in angle brackets, and " +
+			"must not give an identity twice, matched by email address after the repository's .mailmap maps it to a " +
+			"canonical one. Identities can be required to be in the .mailmap or in an allowlist file of the " +
+			"repository, with one identity per line. The rule is disabled by default.",
+		Help:     "Write identities as \"Name <email>\" and remove duplicate trailers.",
+		Disabled: true,
+		Options: []commitlinter.OptionDefinition{
+			{
+				Name:        "mailmap",
+				Type:        "bool",
+				Default:     "false",
+				Description: "require identities to be in the repository's .mailmap",
+			},
+			{
+				Name:        "allowlist",
+				Type:        "string",
+				Default:     "none",
+				Description: "path of a file in the repository with the allowed identities",
+			},
+		},
+		New: func(config commitlinter.RuleConfig) (commitlinter.RuleFunc, error) {
+			var opts identityTrailersOptions
+			if err := config.Decode(&opts); err != nil {
+				return nil, err
+			}
+
+			if !opts.Mailmap && opts.Allowlist == "" {
+				return IdentityTrailers(config.Mailmap, nil), nil
+			}
+
+			var allowed []commitparser.Identity
+
+			if opts.Allowlist != "" {
+				if config.Repository == nil {
+					return nil, fmt.Errorf("allowlist: %w", commitlinter.ErrNoRepository)
+				}
+
+				f, err := repofile.Open(config.Repository, opts.Allowlist)
+				if err != nil {
+					return nil, err
+				}
+
+				defer func() {
+					_ = f.Close()
+				}()
+
+				if allowed, err = ParseAllowlist(f); err != nil {
+					return nil, fmt.Errorf("%s: %w", opts.Allowlist, err)
+				}
+			}
+
+			known := func(id commitparser.Identity) bool {
+				if opts.Mailmap && config.Mailmap.Knows(id.Email) {
+					return true
+				}
+
+				return slices.ContainsFunc(allowed, func(allowed commitparser.Identity) bool {
+					return strings.EqualFold(allowed.Email, id.Email)
+				})
+			}
+
+			return IdentityTrailers(config.Mailmap, known), nil
+		},
+	},
+	{
+		ID:    RuleIssueReference,
+		Title: "Issue referenced",
+		Description: "Commits of some types, feat and fix by default, must reference an issue or a ticket, e.g. #123, " +
+			"or ABC-123 if ABC is one of the projects. No projects are configured by default, so tickets like " +
+			"ABC-123 aren't matched until they are. References are matched by regular expressions and looked for in " +
+			"the locations, which can be the subject, the body or trailer keys, by default the subject, the body and " +
+			"the Refs, Closes, Fixes and Resolves trailers. An empty list of types requires every commit to reference " +
+			"an issue. Merge commits are not checked. The rule is disabled by default.",
+		Help: "Reference the issue that the commit resolves, e.g. in a Refs trailer. Tickets like ABC-123 are only " +
+			"references if ABC is in the projects option.",
+		Disabled: true,
+		Options: []commitlinter.OptionDefinition{
+			{
+				Name:        "types",
+				Type:        "[]string",
+				Default:     strings.Join(DefaultReferenceTypes, ","),
+				Description: "types of commits that must reference an issue, every type if empty",
+			},
+			{
+				Name:        "patterns",
+				Type:        "[]string",
+				Default:     patternList(commitparser.DefaultReferencePatterns),
+				Description: "regular expressions of references, the submatch named id is the reference if there is one",
+			},
+			{
+				Name:        "projects",
+				Type:        "[]string",
+				Default:     "none",
+				Description: "keys of projects whose Jira-style tickets are references, e.g. ABC for ABC-123",
+			},
+			{
+				Name:        "locations",
+				Type:        "[]string",
+				Default:     strings.Join(DefaultReferenceLocations, ","),
+				Description: "where references are looked for, subject, body or a trailer key",
+			},
+		},
+		New: func(config commitlinter.RuleConfig) (commitlinter.RuleFunc, error) {
+			// The defaults are cloned since decoding reuses the backing arrays of slices.
+			opts := issueReferenceOptions{
+				Types:     slices.Clone(DefaultReferenceTypes),
+				Locations: slices.Clone(DefaultReferenceLocations),
+			}
+			if err := config.Decode(&opts); err != nil {
+				return nil, err
+			}
+
+			patterns, err := compilePatterns(opts.Patterns, commitparser.DefaultReferencePatterns)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(opts.Projects) > 0 {
+				patterns = append(slices.Clone(patterns), commitparser.TicketPattern(opts.Projects...))
+			}
+
+			return IssueReference(opts.Types, patterns, opts.Locations), nil
+		},
+	},
+	{
+		ID:    RuleRevertedCommit,
+		Title: "Reverted commit named",
+		Description: "A revert must tell which commits it reverts, either by the \"This reverts commit <hash>.\" line " +
+			"that git revert adds, or by a Refs trailer with the hashes. The reverted commits must exist, and a revert " +
+			"of a single commit that quotes its header, like git's `Revert \"feat: add foo\"`, must quote it as it " +
+			"is. Shallow clones may lack the reverted commits. The rule is disabled by default.",
+		Help:     "Use git revert, or name the reverted commits in a Refs trailer.",
+		Disabled: true,
+		New: func(config commitlinter.RuleConfig) (commitlinter.RuleFunc, error) {
+			return RevertedCommit(config.Repository), nil
+		},
+	},
+	{
+		ID:    RuleHeaderMaxLength,
+		Title: "Header width",
+		Description: "The header must not be wider than the limit. Width is measured in columns of a terminal, so " +
+			"wide characters like CJK and most emoji count as two columns. Headers that are too wide are cut off by " +
+			"tools that show one-line logs. The rule is disabled by default.",
+		Help:     "Shorten the subject, details belong in the body.",
+		Disabled: true,
+		Options:  []commitlinter.OptionDefinition{maxOption},
+		New: func(config commitlinter.RuleConfig) (commitlinter.RuleFunc, error) {
+			opts := maxLengthOptions{Max: DefaultMaxLength}
+			if err := config.Decode(&opts); err != nil {
+				return nil, err
+			}
+
+			return HeaderMaxLength(opts.Max), nil
+		},
+	},
+	{
+		ID:    RuleHeaderMinLength,
+		Title: "Header minimum width",
+		Description: "The header must be at least as wide as the minimum, measured in columns of a terminal. A " +
+			"header like \"fix: bug\" doesn't tell what changed. The rule is disabled by default.",
+		Help:     "Describe the change in the subject.",
+		Disabled: true,
+		Options: []commitlinter.OptionDefinition{
+			{
+				Name:        "min",
+				Type:        "int",
+				Default:     strconv.Itoa(DefaultMinLength),
+				Description: "minimum number of columns",
+			},
+		},
+		New: func(config commitlinter.RuleConfig) (commitlinter.RuleFunc, error) {
+			opts := minLengthOptions{Min: DefaultMinLength}
+			if err := config.Decode(&opts); err != nil {
+				return nil, err
+			}
+
+			return HeaderMinLength(opts.Min), nil
+		},
+	},
+	{
+		ID:    RuleBodyMaxLineLength,
+		Title: "Body line width",
+		Description: "Each line of the body must not be wider than the limit, measured in columns of a terminal. " +
+			"Git doesn't wrap commit messages, so long lines are hard to read in a terminal. Lines with URLs are " +
+			"exempt since URLs can't be wrapped. The rule is disabled by default.",
+		Help:     "Wrap the lines of the body.",
+		Disabled: true,
+		Options:  []commitlinter.OptionDefinition{maxOption},
+		New: func(config commitlinter.RuleConfig) (commitlinter.RuleFunc, error) {
+			opts := maxLengthOptions{Max: DefaultMaxLength}
+			if err := config.Decode(&opts); err != nil {
+				return nil, err
+			}
+
+			return BodyMaxLineLength(opts.Max), nil
+		},
+	},
+	{
+		ID:    RuleFooterMaxLineLength,
+		Title: "Footer line width",
+		Description: "Each line of the trailers must not be wider than the limit, measured in columns of a " +
+			"terminal. Lines with URLs are exempt, and so is the first line of each trailer, however long its " +
+			"value is, but not the lines that a value is continued on. The rule is disabled by default.",
+		Help:     "Wrap the continuation lines of the trailer value, each indented by a space.",
+		Disabled: true,
+		Options:  []commitlinter.OptionDefinition{maxOption},
+		New: func(config commitlinter.RuleConfig) (commitlinter.RuleFunc, error) {
+			opts := maxLengthOptions{Max: DefaultMaxLength}
+			if err := config.Decode(&opts); err != nil {
+				return nil, err
+			}
+
+			return FooterMaxLineLength(opts.Max), nil
+		},
+	},
+}
+
+var maxOption = commitlinter.OptionDefinition{
+	Name:        "max",
+	Type:        "int",
+	Default:     strconv.Itoa(DefaultMaxLength),
+	Description: "maximum number of columns",
+}
+
+// patternList returns the patterns as the default of an option.
+func patternList(patterns []*regexp.Regexp) string {
+	exprs := make([]string, 0, len(patterns))
+
+	for _, pattern := range patterns {
+		exprs = append(exprs, pattern.String())
+	}
+
+	return strings.Join(exprs, ",")
+}
+
+// compilePatterns compiles the regular expressions of an option, or returns the default patterns if the option isn't
+// set.
+func compilePatterns(exprs []string, defaults []*regexp.Regexp) ([]*regexp.Regexp, error) {
+	if exprs == nil {
+		return defaults, nil
+	}
+
+	patterns := make([]*regexp.Regexp, 0, len(exprs))
+
+	for _, expr := range exprs {
+		pattern, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("bad pattern: %w", err)
+		}
+
+		patterns = append(patterns, pattern)
+	}
+
+	return patterns, nil
+}
+
+func staticRule(rule commitlinter.RuleFunc) func(commitlinter.RuleConfig) (commitlinter.RuleFunc, error) {
+	return func(_ commitlinter.RuleConfig) (commitlinter.RuleFunc, error) {
+		return rule, nil
+	}
+}
@@ -0,0 +1,77 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package conventionalcommits
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+)
+
+// RevertedCommit returns a rule that requires reverts to tell which commits they revert, see
+// [commitparser.CommitMessage.RevertedHashes]. If the repository isn't nil, the reverted commits must exist in it, and
+// a revert of a single commit that quotes a header must quote the header of that commit.
+func RevertedCommit(repo *git.Repository) commitlinter.RuleFunc {
+	return func(msg commitparser.CommitMessage, commit *object.Commit) error {
+		if !msg.Revert {
+			return nil
+		}
+
+		if len(msg.RevertedHashes) == 0 {
+			err := fmt.Errorf("revert doesn't tell which commit it reverts: %w", commitlinter.ErrRevertedCommit)
+
+			return commitlinter.WithSuggestion(commitlinter.ErrorAt(0, err),
+				`add the line "This reverts commit <hash>." to the body`)
+		}
+
+		if repo == nil {
+			return nil
+		}
+
+		for _, hash := range msg.RevertedHashes {
+			pos := 0
+			if commit != nil {
+				pos = max(strings.Index(commit.Message, hash), 0)
+			}
+
+			reverted, err := resolveCommit(repo, hash)
+			if err != nil {
+				return commitlinter.ErrorAt(pos, fmt.Errorf("reverted commit %s isn't in the repository (%w): %w", hash,
+					err, commitlinter.ErrRevertedCommit))
+			}
+
+			header, _, _ := strings.Cut(reverted.Message, "\n")
+
+			if msg.Reverted != nil && len(msg.RevertedHashes) == 1 && msg.Subject != header {
+				err = fmt.Errorf("revert quotes %q, but commit %s has the header %q: %w", msg.Subject, hash, header,
+					commitlinter.ErrRevertedCommit)
+
+				return commitlinter.WithSuggestion(commitlinter.ErrorAt(commitlinter.SubjectPos(msg, commit), err),
+					fmt.Sprintf("quote the header %q", header))
+			}
+		}
+
+		return nil
+	}
+}
+
+// resolveCommit returns the commit with the hash, which may be abbreviated.
+func resolveCommit(repo *git.Repository, hash string) (*object.Commit, error) {
+	resolved, err := repo.ResolveRevision(plumbing.Revision(hash))
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.CommitObject(*resolved)
+}
@@ -0,0 +1,115 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package conventionalcommits_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitlinter/conventionalcommits"
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+	"codeberg.org/somebadcode/commit-tool/internal/repobuilder"
+)
+
+func TestRevertedCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, err := repobuilder.Build(repobuilder.Commit("feat: add foo", git.CommitOptions{
+		AllowEmptyCommits: true,
+		Author: &object.Signature{
+			Name:  "Gopher",
+			Email: "gopher@example.com",
+			When:  time.Date(2023, 2, 4, 23, 22, 0, 0, time.UTC),
+		},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash := head.Hash().String()
+
+	tests := []struct {
+		name           string
+		rule           commitlinter.RuleFunc
+		message        string
+		wantErr        error
+		wantSuggestion string
+	}{
+		{
+			name:    "git_revert",
+			rule:    conventionalcommits.RevertedCommit(repo),
+			message: "Revert \"feat: add foo\"\n\nThis reverts commit " + hash + ".\n",
+		},
+		{
+			name:    "refs_trailer",
+			rule:    conventionalcommits.RevertedCommit(repo),
+			message: "revert: add foo after all\n\nRefs: " + hash[:7] + "\n",
+		},
+		{
+			name:           "wrong_header",
+			rule:           conventionalcommits.RevertedCommit(repo),
+			message:        "Revert \"feat: add bar\"\n\nThis reverts commit " + hash + ".\n",
+			wantErr:        commitlinter.ErrRevertedCommit,
+			wantSuggestion: `quote the header "feat: add foo"`,
+		},
+		{
+			name:    "unknown_commit",
+			rule:    conventionalcommits.RevertedCommit(repo),
+			message: "Revert \"feat: add foo\"\n\nThis reverts commit 8f1c0d6a2b5e4f3a9c7d1e0b2a4c6e8f0a1b3c5d.\n",
+			wantErr: commitlinter.ErrRevertedCommit,
+		},
+		{
+			name:           "no_hash",
+			rule:           conventionalcommits.RevertedCommit(repo),
+			message:        "revert: feat: add foo\n",
+			wantErr:        commitlinter.ErrRevertedCommit,
+			wantSuggestion: `add the line "This reverts commit <hash>." to the body`,
+		},
+		{
+			name:    "no_repository",
+			rule:    conventionalcommits.RevertedCommit(nil),
+			message: "Revert \"feat: add bar\"\n\nThis reverts commit 8f1c0d6a2b5e4f3a9c7d1e0b2a4c6e8f0a1b3c5d.\n",
+		},
+		{
+			name:    "not_a_revert",
+			rule:    conventionalcommits.RevertedCommit(repo),
+			message: "feat: add bar\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			msg, err := commitparser.Parse(tt.message)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			err = tt.rule(msg, &object.Commit{Message: tt.message})
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("rule error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var suggestionError commitlinter.SuggestionError
+			if errors.As(err, &suggestionError); suggestionError.Suggestion != tt.wantSuggestion {
+				t.Errorf("rule suggestion = %q, want %q", suggestionError.Suggestion, tt.wantSuggestion)
+			}
+		})
+	}
+}
@@ -19,69 +19,153 @@ import (
 	"codeberg.org/somebadcode/commit-tool/commitparser"
 )
 
-var conventionalTypes = map[string]struct{}{
-	"build":    {},
-	"chore":    {},
-	"ci":       {},
-	"docs":     {},
-	"feat":     {},
-	"fix":      {},
-	"perf":     {},
-	"refactor": {},
-	"revert":   {},
-	"style":    {},
-	"test":     {},
+// DefaultTypes are the commit types that are allowed unless configured otherwise.
+var DefaultTypes = []string{
+	"build",
+	"chore",
+	"ci",
+	"docs",
+	"feat",
+	"fix",
+	"perf",
+	"refactor",
+	"revert",
+	"style",
+	"test",
 }
 
-// VerifySubject verifies that the commit message's subject is not empty and does not start with upper case.
-func VerifySubject(msg commitparser.CommitMessage, _ *object.Commit) error {
+var conventionalTypes = TypeEnum(DefaultTypes...)
+
+// VerifySubject verifies that the commit message's subject is not empty, does not start with upper case and does not
+// end with punctuation.
+func VerifySubject(msg commitparser.CommitMessage, commit *object.Commit) error {
+	return commitlinter.Rules{
+		VerifySubjectEmpty,
+		VerifySubjectCase,
+		VerifySubjectFullStop,
+	}.Validate(msg, commit)
+}
+
+// VerifySubjectEmpty verifies that the commit message's subject is not empty and does not start with space.
+func VerifySubjectEmpty(msg commitparser.CommitMessage, commit *object.Commit) error {
+	pos := commitlinter.SubjectPos(msg, commit)
+
 	first, size := utf8.DecodeRuneInString(msg.Subject)
 	if first == utf8.RuneError && size == 0 {
-		return fmt.Errorf("subject must not be empty: %w", commitlinter.ErrInvalidSubject)
+		return commitlinter.ErrorAt(pos, fmt.Errorf("subject must not be empty: %w", commitlinter.ErrInvalidSubject))
 	} else if first == utf8.RuneError && size == 1 {
-		return fmt.Errorf("bad subject: %w", commitlinter.ErrInvalidCharacter)
+		return commitlinter.ErrorAt(pos, fmt.Errorf("bad subject: %w", commitlinter.ErrInvalidCharacter))
 	}
 
+	if unicode.IsSpace(first) {
+		return commitlinter.ErrorAt(pos, fmt.Errorf("subject must not start with space %q: %w", msg.Subject, commitlinter.ErrInvalidCharacter))
+	}
+
+	return nil
+}
+
+// VerifySubjectCase verifies that the commit message's subject does not start with upper case. The headers that git
+// writes for reverts and merges are not checked.
+func VerifySubjectCase(msg commitparser.CommitMessage, commit *object.Commit) error {
+	if msg.Revert || msg.Merge {
+		return nil
+	}
+
+	first, _ := utf8.DecodeRuneInString(msg.Subject)
+
 	if unicode.IsUpper(first) {
-		return fmt.Errorf("subject must not start with upper case %q: %w", msg.Subject, commitlinter.ErrInvalidCharacter)
+		return commitlinter.ErrorAt(commitlinter.SubjectPos(msg, commit),
+			fmt.Errorf("subject must not start with upper case %q: %w", msg.Subject, commitlinter.ErrInvalidCharacter))
 	}
 
-	if unicode.IsSpace(first) {
-		return fmt.Errorf("subject must not start with space %q: %w", msg.Subject, commitlinter.ErrInvalidCharacter)
+	return nil
+}
+
+// VerifySubjectFullStop verifies that the commit message's subject does not end with punctuation. The headers that git
+// writes for reverts and merges are not checked, since they end with a quote.
+func VerifySubjectFullStop(msg commitparser.CommitMessage, commit *object.Commit) error {
+	if msg.Revert || msg.Merge {
+		return nil
 	}
 
-	var last rune
+	last, size := utf8.DecodeLastRuneInString(msg.Subject)
+	pos := commitlinter.SubjectPos(msg, commit) + len(msg.Subject) - size
 
-	last, size = utf8.DecodeRuneInString(msg.Subject)
-	if first == utf8.RuneError && size == 0 {
-		return fmt.Errorf("unexpectedly short subject: %w", commitlinter.ErrInvalidSubject)
-	} else if first == utf8.RuneError && size == 1 {
-		return fmt.Errorf("bad subject: %w", commitlinter.ErrInvalidCharacter)
+	if last == utf8.RuneError && size == 1 {
+		return commitlinter.ErrorAt(pos, fmt.Errorf("bad subject: %w", commitlinter.ErrInvalidCharacter))
 	}
 
 	if unicode.IsPunct(last) {
-		return fmt.Errorf("subject must now end with punctuation %q: %w", msg.Subject, commitlinter.ErrInvalidCharacter)
+		return commitlinter.ErrorAt(pos, fmt.Errorf("subject must not end with punctuation %q: %w", msg.Subject, commitlinter.ErrInvalidCharacter))
 	}
 
 	return nil
 }
 
-func VerifyType(msg commitparser.CommitMessage, _ *object.Commit) error {
-	if _, found := conventionalTypes[msg.Type]; !found {
-		return fmt.Errorf("unknown type %q: %w", msg.Type, commitlinter.ErrInvalidType)
+func VerifyType(msg commitparser.CommitMessage, commit *object.Commit) error {
+	return conventionalTypes(msg, commit)
+}
+
+// TypeEnum returns a rule that only allows the given commit types. Reverts and merge commits are allowed too, since git
+// writes their headers.
+func TypeEnum(types ...string) commitlinter.RuleFunc {
+	allowed := make(map[string]struct{}, len(types))
+	for _, t := range types {
+		allowed[t] = struct{}{}
 	}
 
-	return nil
+	return func(msg commitparser.CommitMessage, _ *object.Commit) error {
+		if msg.Revert || msg.Merge {
+			return nil
+		}
+
+		if _, found := allowed[msg.Type]; !found {
+			return fmt.Errorf("unknown type %q: %w", msg.Type, commitlinter.ErrInvalidType)
+		}
+
+		return nil
+	}
 }
 
+// VerifyScope verifies that the commit message's scope does not start or end with space.
 func VerifyScope(msg commitparser.CommitMessage, _ *object.Commit) error {
 	if strings.TrimSpace(msg.Scope) != msg.Scope {
-		return fmt.Errorf("")
+		return commitlinter.ErrorAt(commitlinter.ScopePos(msg),
+			fmt.Errorf("scope must not start or end with space %q: %w", msg.Scope, commitlinter.ErrInvalidScope))
 	}
 
 	return nil
 }
 
+// ScopeEnum returns a rule that only allows the given scopes, see [VerifyScope]. Any scope is allowed if no scopes are
+// given. If required is true then commit messages without a scope are rejected.
+func ScopeEnum(required bool, scopes ...string) commitlinter.RuleFunc {
+	allowed := make(map[string]struct{}, len(scopes))
+	for _, s := range scopes {
+		allowed[s] = struct{}{}
+	}
+
+	return func(msg commitparser.CommitMessage, _ *object.Commit) error {
+		if msg.Scope == "" {
+			if required {
+				return fmt.Errorf("scope must not be empty: %w", commitlinter.ErrInvalidScope)
+			}
+
+			return nil
+		}
+
+		if err := VerifyScope(msg, nil); err != nil {
+			return err
+		}
+
+		if _, found := allowed[msg.Scope]; len(allowed) > 0 && !found {
+			return commitlinter.ErrorAt(commitlinter.ScopePos(msg), fmt.Errorf("unknown scope %q: %w", msg.Scope, commitlinter.ErrInvalidScope))
+		}
+
+		return nil
+	}
+}
+
 func Verify(msg commitparser.CommitMessage, commit *object.Commit) error {
 	if err := VerifyType(msg, commit); err != nil {
 		return err
@@ -0,0 +1,93 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package conventionalcommits
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+	"codeberg.org/somebadcode/commit-tool/internal/changes"
+	"codeberg.org/somebadcode/commit-tool/suggest"
+)
+
+// ScopePath returns a rule that checks that a commit changes files that are covered by its scope, and no files that
+// are only covered by other scopes. Paths maps scopes to gitignore-style patterns, commits with a scope that isn't
+// mapped are not checked. The commit is diffed against its first parent, merge commits are skipped. The files of a
+// message that isn't committed yet are the files that are staged in the repository, the message isn't checked without
+// one.
+func ScopePath(paths map[string][]string, repo *git.Repository) commitlinter.RuleFunc {
+	areas := suggest.NewAreas(paths)
+	scopes := slices.Sorted(maps.Keys(areas))
+
+	return func(msg commitparser.CommitMessage, commit *object.Commit) error {
+		if _, found := areas[msg.Scope]; !found || commit == nil || commit.NumParents() > 1 {
+			return nil
+		}
+
+		files, err := changes.Commit(repo, commit)
+		if errors.Is(err, changes.ErrNotCommitted) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		covered := false
+
+		// The other scopes that the commit changes files of, with the first such file.
+		others := make(map[string]string)
+
+		for _, file := range files {
+			if areas.Covers(msg.Scope, file) {
+				covered = true
+
+				continue
+			}
+
+			for _, scope := range scopes {
+				if _, seen := others[scope]; !seen && areas.Covers(scope, file) {
+					others[scope] = file
+				}
+			}
+		}
+
+		pos := commitlinter.ScopePos(msg)
+
+		if len(others) > 0 {
+			var changes []string
+			for _, scope := range slices.Sorted(maps.Keys(others)) {
+				changes = append(changes, fmt.Sprintf("%s (%s)", scope, others[scope]))
+			}
+
+			err = commitlinter.ErrorAt(pos, fmt.Errorf("commit with scope %q also changes files of other scopes: %s: %w",
+				msg.Scope, strings.Join(changes, ", "), commitlinter.ErrScopePath))
+
+			// The commit changes files of a single other scope only, so that's probably the scope it should have.
+			if !covered && len(others) == 1 {
+				suggestion := suggest.Suggestion{Type: msg.Type, Scope: slices.Collect(maps.Keys(others))[0]}
+				err = commitlinter.WithSuggestion(err, didYouMean(suggestion))
+			}
+
+			return err
+		}
+
+		if !covered && len(files) > 0 {
+			return commitlinter.ErrorAt(pos, fmt.Errorf("commit with scope %q changes no files in %s: %w",
+				msg.Scope, strings.Join(paths[msg.Scope], ", "), commitlinter.ErrScopePath))
+		}
+
+		return nil
+	}
+}
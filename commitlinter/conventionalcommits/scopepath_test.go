@@ -0,0 +1,370 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package conventionalcommits_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitlinter/conventionalcommits"
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+	"codeberg.org/somebadcode/commit-tool/internal/repobuilder"
+)
+
+var commitOpts = git.CommitOptions{
+	Author: &object.Signature{
+		Name:  "Gopher",
+		Email: "gopher@example.com",
+		When:  time.Date(2023, 2, 4, 23, 22, 0, 0, time.UTC),
+	},
+}
+
+func TestScopePath(t *testing.T) {
+	t.Parallel()
+
+	rule := conventionalcommits.ScopePath(map[string][]string{
+		"api": {"services/api/**"},
+		"web": {"services/web/**", "!services/web/README.md"},
+	}, nil)
+
+	tests := []struct {
+		name           string
+		files          []string
+		message        string
+		wantErr        error
+		wantSuggestion string
+	}{
+		{
+			name:    "in_scope",
+			files:   []string{"services/api/main.go", "go.mod"},
+			message: "fix(api): handle empty input",
+		},
+		{
+			name:    "outside_scope",
+			files:   []string{"go.mod"},
+			message: "fix(api): handle empty input",
+			wantErr: commitlinter.ErrScopePath,
+		},
+		{
+			name:    "several_scopes",
+			files:   []string{"services/api/main.go", "services/web/index.html"},
+			message: "fix(api): handle empty input",
+			wantErr: commitlinter.ErrScopePath,
+		},
+		{
+			name:           "other_scope",
+			files:          []string{"services/web/index.html"},
+			message:        "fix(api): handle empty input",
+			wantErr:        commitlinter.ErrScopePath,
+			wantSuggestion: `did you mean "fix(web):"?`,
+		},
+		{
+			name:    "excluded_file",
+			files:   []string{"services/api/main.go", "services/web/README.md"},
+			message: "fix(api): handle empty input",
+		},
+		{
+			name:    "unmapped_scope",
+			files:   []string{"services/web/index.html"},
+			message: "docs(readme): explain the services",
+		},
+		{
+			name:    "no_scope",
+			files:   []string{"services/api/main.go", "services/web/index.html"},
+			message: "chore: update dependencies",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			msg, err := commitparser.Parse(tt.message)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			commit := commitWithFiles(t, tt.message, tt.files)
+
+			err = rule(msg, commit)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("rule error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var suggestionError commitlinter.SuggestionError
+			if errors.As(err, &suggestionError); suggestionError.Suggestion != tt.wantSuggestion {
+				t.Errorf("rule suggestion = %q, want %q", suggestionError.Suggestion, tt.wantSuggestion)
+			}
+		})
+	}
+}
+
+func TestScopePath_notCommitted(t *testing.T) {
+	t.Parallel()
+
+	paths := map[string][]string{
+		"api": {"services/api/**"},
+		"web": {"services/web/**"},
+	}
+
+	repo := stageFiles(t, "package main", []string{"services/web/main.go"})
+
+	tests := []struct {
+		name           string
+		repo           *git.Repository
+		message        string
+		wantErr        error
+		wantSuggestion string
+	}{
+		{
+			name:    "in_scope",
+			repo:    repo,
+			message: "fix(web): handle empty input",
+		},
+		{
+			name:           "other_scope",
+			repo:           repo,
+			message:        "fix(api): handle empty input",
+			wantErr:        commitlinter.ErrScopePath,
+			wantSuggestion: `did you mean "fix(web):"?`,
+		},
+		{
+			// Without a repository, there are no staged files to check.
+			name:    "no_repository",
+			message: "fix(api): handle empty input",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule := conventionalcommits.ScopePath(paths, tt.repo)
+
+			msg, err := commitparser.Parse(tt.message)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			commit := pendingCommit(t, repo, tt.message)
+
+			err = rule(msg, commit)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("rule error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var suggestionError commitlinter.SuggestionError
+			if errors.As(err, &suggestionError); suggestionError.Suggestion != tt.wantSuggestion {
+				t.Errorf("rule suggestion = %q, want %q", suggestionError.Suggestion, tt.wantSuggestion)
+			}
+		})
+	}
+}
+
+func TestTypePath(t *testing.T) {
+	t.Parallel()
+
+	rule := conventionalcommits.TypePath(
+		map[string][]string{
+			"docs": {"docs/**", "*.md"},
+		},
+		map[string][]string{
+			"api": {"services/api/**"},
+		},
+		nil,
+	)
+
+	tests := []struct {
+		name           string
+		files          []string
+		message        string
+		wantErr        error
+		wantSuggestion string
+	}{
+		{
+			name:    "matching_type",
+			files:   []string{"docs/usage.md"},
+			message: "docs: explain usage",
+		},
+		{
+			name:           "other_type",
+			files:          []string{"docs/usage.md", "CHANGELOG.md"},
+			message:        "feat: explain usage",
+			wantErr:        commitlinter.ErrTypePath,
+			wantSuggestion: `did you mean "docs:"?`,
+		},
+		{
+			name:           "other_type_of_scope",
+			files:          []string{"services/api/README.md"},
+			message:        "fix: explain the api",
+			wantErr:        commitlinter.ErrTypePath,
+			wantSuggestion: `did you mean "docs(api):"?`,
+		},
+		{
+			name:    "not_only_docs",
+			files:   []string{"docs/usage.md", "main.go"},
+			message: "feat: add usage",
+		},
+		{
+			name:    "revert",
+			files:   []string{"docs/usage.md"},
+			message: "revert: explain usage",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			msg, err := commitparser.Parse(tt.message)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			commit := commitWithFiles(t, tt.message, tt.files)
+
+			err = rule(msg, commit)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("rule error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var suggestionError commitlinter.SuggestionError
+			if errors.As(err, &suggestionError); suggestionError.Suggestion != tt.wantSuggestion {
+				t.Errorf("rule suggestion = %q, want %q", suggestionError.Suggestion, tt.wantSuggestion)
+			}
+		})
+	}
+}
+
+func TestTypePath_notCommitted(t *testing.T) {
+	t.Parallel()
+
+	types := map[string][]string{
+		"docs": {"docs/**", "*.md"},
+	}
+
+	repo := stageFiles(t, "Usage", []string{"docs/usage.md"})
+
+	tests := []struct {
+		name           string
+		repo           *git.Repository
+		message        string
+		wantErr        error
+		wantSuggestion string
+	}{
+		{
+			name:    "matching_type",
+			repo:    repo,
+			message: "docs: explain usage",
+		},
+		{
+			name:           "other_type",
+			repo:           repo,
+			message:        "feat: explain usage",
+			wantErr:        commitlinter.ErrTypePath,
+			wantSuggestion: `did you mean "docs:"?`,
+		},
+		{
+			// Without a repository, there are no staged files to check.
+			name:    "no_repository",
+			message: "feat: explain usage",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule := conventionalcommits.TypePath(types, nil, tt.repo)
+
+			msg, err := commitparser.Parse(tt.message)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			commit := pendingCommit(t, repo, tt.message)
+
+			err = rule(msg, commit)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("rule error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var suggestionError commitlinter.SuggestionError
+			if errors.As(err, &suggestionError); suggestionError.Suggestion != tt.wantSuggestion {
+				t.Errorf("rule suggestion = %q, want %q", suggestionError.Suggestion, tt.wantSuggestion)
+			}
+		})
+	}
+}
+
+// stageFiles stages the files, on top of a commit that adds a readme, and returns the repository.
+func stageFiles(t *testing.T, content string, files []string) *git.Repository {
+	t.Helper()
+
+	ops := []repobuilder.OperationFunc{
+		repobuilder.WriteFile("README.md", "Services\n"),
+		repobuilder.Commit("docs: add readme", commitOpts),
+	}
+
+	for _, file := range files {
+		ops = append(ops, repobuilder.WriteFile(file, content+"\n"))
+	}
+
+	repo, err := repobuilder.Build(ops...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return repo
+}
+
+// commitWithFiles commits the files with the message, on top of a commit that adds a readme, and returns the commit.
+func commitWithFiles(t *testing.T, message string, files []string) *object.Commit {
+	t.Helper()
+
+	repo := stageFiles(t, message, files)
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = repobuilder.Commit(message, commitOpts)(repo, worktree); err != nil {
+		t.Fatal(err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return commit
+}
+
+// pendingCommit returns a commit of the message that isn't created yet, with HEAD as its parent.
+func pendingCommit(t *testing.T, repo *git.Repository, message string) *object.Commit {
+	t.Helper()
+
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &object.Commit{Message: message, ParentHashes: []plumbing.Hash{head.Hash()}}
+}
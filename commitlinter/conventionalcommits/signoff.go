@@ -0,0 +1,91 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package conventionalcommits
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+	"codeberg.org/somebadcode/commit-tool/mailmap"
+)
+
+// SignedOffBy returns a rule that requires a Signed-off-by trailer by the author of the commit, or by one of the
+// signers, e.g. "Release Bot <bot@example.com>". Identities are matched by email address, after the identities mailmap
+// maps them to canonical ones, which may be nil. A message without a commit only needs a Signed-off-by trailer.
+func SignedOffBy(signers []string, identities *mailmap.Mailmap) commitlinter.RuleFunc {
+	allowed := make([]string, 0, len(signers))
+
+	for _, signer := range signers {
+		id, err := commitparser.ParseIdentity(signer)
+		if err != nil {
+			// A signer may be given as only an email address.
+			id = commitparser.Identity{Email: strings.Trim(strings.TrimSpace(signer), "<>")}
+		}
+
+		_, email := identities.Map(id.Name, id.Email)
+		allowed = append(allowed, email)
+	}
+
+	signedBy := func(email string) bool {
+		return slices.ContainsFunc(allowed, func(signer string) bool {
+			return strings.EqualFold(signer, email)
+		})
+	}
+
+	return func(msg commitparser.CommitMessage, commit *object.Commit) error {
+		signatures := msg.Identities()[commitparser.TrailerKeySignedOffBy]
+
+		if commit == nil {
+			if len(signatures) > 0 {
+				return nil
+			}
+
+			return commitlinter.ErrorAt(0, fmt.Errorf("no %s trailer: %w", commitparser.TrailerKeySignedOffBy,
+				commitlinter.ErrNotSignedOff))
+		}
+
+		_, author := identities.Map(commit.Author.Name, commit.Author.Email)
+
+		for _, signature := range signatures {
+			_, email := identities.Map(signature.Name, signature.Email)
+
+			if strings.EqualFold(email, author) || signedBy(email) {
+				return nil
+			}
+		}
+
+		var err error
+		if len(signatures) == 0 {
+			err = fmt.Errorf("no %s trailer: %w", commitparser.TrailerKeySignedOffBy, commitlinter.ErrNotSignedOff)
+		} else {
+			err = fmt.Errorf("no %s trailer by the author %s <%s>: %w", commitparser.TrailerKeySignedOffBy,
+				commit.Author.Name, commit.Author.Email, commitlinter.ErrNotSignedOff)
+		}
+
+		// A Signed-off-by trailer belongs at the end of the message if there are no trailers.
+		pos := len(strings.TrimRight(commit.Message, "\n"))
+		if msg.Separation.FooterPos > 0 {
+			pos = commitlinter.TrailerPos(msg, commit, commitparser.TrailerKeySignedOffBy, "")
+		}
+
+		err = commitlinter.ErrorAt(pos, err)
+
+		if commit.Author.Email == "" {
+			return err
+		}
+
+		// The trailer is what git commit --signoff adds, given that the author is also the committer.
+		return commitlinter.WithSuggestion(err, fmt.Sprintf("add the trailer %q", fmt.Sprintf("%s: %s <%s>",
+			commitparser.TrailerKeySignedOffBy, commit.Author.Name, commit.Author.Email)))
+	}
+}
@@ -0,0 +1,151 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package conventionalcommits_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitlinter/conventionalcommits"
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+	"codeberg.org/somebadcode/commit-tool/mailmap"
+)
+
+func TestSignedOffBy(t *testing.T) {
+	t.Parallel()
+
+	identities, err := mailmap.Parse(strings.NewReader("Gopher <gopher@example.com> <gopher@old.example.com>\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	rule := conventionalcommits.SignedOffBy([]string{"Release Bot <bot@example.com>", "ci@example.com"}, identities)
+
+	author := object.Signature{
+		Name:  "Gopher",
+		Email: "gopher@example.com",
+	}
+
+	const wantSuggestion = `add the trailer "Signed-off-by: Gopher <gopher@example.com>"`
+
+	tests := []struct {
+		name           string
+		message        string
+		author         object.Signature
+		wantErr        error
+		wantPos        int
+		wantSuggestion string
+	}{
+		{
+			name:    "signed_off",
+			message: "fix: bug\n\nSigned-off-by: Gopher <gopher@example.com>\n",
+			author:  author,
+		},
+		{
+			name:    "case_insensitive_email",
+			message: "fix: bug\n\nSigned-off-by: Gopher <Gopher@Example.com>\n",
+			author:  author,
+		},
+		{
+			name:    "several_signers",
+			message: "fix: bug\n\nSigned-off-by: Someone <someone@example.com>\nSigned-off-by: Gopher <gopher@example.com>\n",
+			author:  author,
+		},
+		{
+			name:    "mailmap",
+			message: "fix: bug\n\nSigned-off-by: Gopher <gopher@old.example.com>\n",
+			author:  author,
+		},
+		{
+			name:    "mailmap_author",
+			message: "fix: bug\n\nSigned-off-by: Gopher <gopher@example.com>\n",
+			author: object.Signature{
+				Name:  "Gopher",
+				Email: "gopher@old.example.com",
+			},
+		},
+		{
+			name:    "allowed_signer",
+			message: "fix: bug\n\nSigned-off-by: Release Bot <bot@example.com>\n",
+			author:  author,
+		},
+		{
+			name:    "allowed_email",
+			message: "fix: bug\n\nSigned-off-by: CI <ci@example.com>\n",
+			author:  author,
+		},
+		{
+			name:           "not_signed_off",
+			message:        "fix: bug\n\nThe body.\n",
+			author:         author,
+			wantErr:        commitlinter.ErrNotSignedOff,
+			wantPos:        19,
+			wantSuggestion: wantSuggestion,
+		},
+		{
+			name:           "other_trailers",
+			message:        "fix: bug\n\nRefs: ABC-123\n",
+			author:         author,
+			wantErr:        commitlinter.ErrNotSignedOff,
+			wantPos:        10,
+			wantSuggestion: wantSuggestion,
+		},
+		{
+			name:           "signed_off_by_other",
+			message:        "fix: bug\n\nRefs: ABC-123\nSigned-off-by: Someone <someone@example.com>\n",
+			author:         author,
+			wantErr:        commitlinter.ErrNotSignedOff,
+			wantPos:        24,
+			wantSuggestion: wantSuggestion,
+		},
+		{
+			name:           "malformed",
+			message:        "fix: bug\n\nSigned-off-by: gopher@example.com\n",
+			author:         author,
+			wantErr:        commitlinter.ErrNotSignedOff,
+			wantPos:        10,
+			wantSuggestion: wantSuggestion,
+		},
+		{
+			name:    "unknown_author",
+			message: "fix: bug\n",
+			wantErr: commitlinter.ErrNotSignedOff,
+			wantPos: 8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			msg, err := commitparser.Parse(tt.message)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			err = rule(msg, &object.Commit{Author: tt.author, Message: tt.message})
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("rule error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var posError commitlinter.PosError
+			if errors.As(err, &posError); posError.Pos != tt.wantPos {
+				t.Errorf("rule error position = %d, want %d", posError.Pos, tt.wantPos)
+			}
+
+			var suggestionError commitlinter.SuggestionError
+			if errors.As(err, &suggestionError); suggestionError.Suggestion != tt.wantSuggestion {
+				t.Errorf("rule suggestion = %q, want %q", suggestionError.Suggestion, tt.wantSuggestion)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package conventionalcommits
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+	"codeberg.org/somebadcode/commit-tool/internal/changes"
+	"codeberg.org/somebadcode/commit-tool/suggest"
+)
+
+// TypePath returns a rule that checks the type of a commit against the type that is suggested from the files that it
+// changes, see [suggest.Suggester.Suggest]. Types and scopes map to gitignore-style patterns, the scopes are only used
+// to suggest a complete header. The files of a message that isn't committed yet are the files that are staged in the
+// repository, the message isn't checked without one. Reverts and merge commits are not checked.
+func TypePath(types map[string][]string, scopes map[string][]string, repo *git.Repository) commitlinter.RuleFunc {
+	suggester := suggest.New(types, scopes)
+
+	return func(msg commitparser.CommitMessage, commit *object.Commit) error {
+		if len(types) == 0 || msg.Revert || commit == nil || commit.NumParents() > 1 {
+			return nil
+		}
+
+		suggestion, err := suggester.SuggestCommit(repo, commit)
+		if errors.Is(err, changes.ErrNotCommitted) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if suggestion.Type == "" || suggestion.Type == msg.Type {
+			return nil
+		}
+
+		if suggestion.Scope == "" {
+			suggestion.Scope = msg.Scope
+		}
+
+		err = fmt.Errorf("commit only changes files in %s, which are of type %q: %w",
+			strings.Join(types[suggestion.Type], ", "), suggestion.Type, commitlinter.ErrTypePath)
+
+		return commitlinter.WithSuggestion(commitlinter.ErrorAt(0, err), didYouMean(suggestion))
+	}
+}
+
+// didYouMean suggests the type and scope of a header, e.g. `did you mean "docs(api):"?`.
+func didYouMean(suggestion suggest.Suggestion) string {
+	return fmt.Sprintf("did you mean %q?", strings.TrimSpace(suggestion.Header()))
+}
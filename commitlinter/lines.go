@@ -0,0 +1,108 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package commitlinter
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+)
+
+// Line is a line of a commit message.
+type Line struct {
+	Text string
+	// Pos is the byte offset of the line into the commit's message, it's 0 if the commit isn't known.
+	Pos int
+}
+
+// HeaderLine returns the first line of the commit's message. Without a commit, the header is made from the parsed
+// message.
+func HeaderLine(msg commitparser.CommitMessage, commit *object.Commit) Line {
+	if commit != nil {
+		header, _, _ := strings.Cut(commit.Message, "\n")
+
+		return Line{Text: header}
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(msg.Type)
+
+	if msg.Scope != "" {
+		sb.WriteString("(" + msg.Scope + ")")
+	}
+
+	if msg.Breaking {
+		sb.WriteString("!")
+	}
+
+	sb.WriteString(": " + msg.Subject)
+
+	return Line{Text: sb.String()}
+}
+
+// BodyLines returns the lines of the commit message's body.
+func BodyLines(msg commitparser.CommitMessage, commit *object.Commit) []Line {
+	if msg.Body == "" {
+		return nil
+	}
+
+	pos := 0
+
+	if commit != nil {
+		pos = msg.Separation.BodyPos
+	}
+
+	return lines(msg.Body, pos, commit != nil)
+}
+
+// FooterLines returns the lines of the commit message's trailers. Without a commit, there is a line for each trailer.
+func FooterLines(msg commitparser.CommitMessage, commit *object.Commit) []Line {
+	if len(msg.Trailers) == 0 {
+		return nil
+	}
+
+	if commit == nil {
+		var footer []Line
+
+		for _, key := range slices.Sorted(maps.Keys(msg.Trailers)) {
+			for _, value := range msg.Trailers[key] {
+				footer = append(footer, Line{Text: key + ": " + value})
+			}
+		}
+
+		return footer
+	}
+
+	// The trailers are the last paragraph of the message.
+	message := strings.TrimRight(commit.Message, "\n")
+	pos := strings.LastIndex(message, "\n\n") + 2
+
+	return lines(message[pos:], pos, true)
+}
+
+func lines(text string, pos int, known bool) []Line {
+	var result []Line
+
+	for line := range strings.Lines(text) {
+		result = append(result, Line{
+			Text: strings.TrimSuffix(line, "\n"),
+			Pos:  pos,
+		})
+
+		if known {
+			pos += len(line)
+		}
+	}
+
+	return result
+}
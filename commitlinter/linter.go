@@ -9,6 +9,9 @@ package commitlinter
 
 import (
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/go-git/go-git/v5/plumbing/object"
 
@@ -16,37 +19,129 @@ import (
 	"codeberg.org/somebadcode/commit-tool/linter"
 )
 
+// RuleHeaderFormat is the ID of the rule that the commit message's header must be a conventional commit header. It
+// can't be disabled since the other rules need the parsed message.
+const RuleHeaderFormat = "header-format"
+
+// AnyRule matches every rule in [Linter.Suppressible].
+const AnyRule = "*"
+
 type Linter struct {
 	Filters Filters
-	Rules   Rules
+	// Prechecks are rules that are checked before the other rules, even if the message can't be parsed, in which case
+	// the parsed message is incomplete. If a precheck fails with an error that isn't suppressed, see [linter.Failed], the
+	// commit isn't linted further, so that a message like "WIP" is reported as such rather than as an invalid header.
+	Prechecks Rules
+	Rules     Rules
+	// Severities are the severities of the rules by ID. Rules that aren't present are errors.
+	Severities map[string]linter.Severity
+	// Suppressible are the IDs of the rules that a commit message can suppress using a Lint-Ignore trailer, or
+	// [AnyRule]. A suppressed rule is still reported, but it doesn't fail.
+	Suppressible []string
 }
 
-// Lint commit to ensures that it adheres to Conventional Commits 1.0.0.
+// Lint commit to ensures that it adheres to Conventional Commits 1.0.0. Every rule is checked, so the error joins a
+// [linter.LintError] for each rule that failed.
 func (l Linter) Lint(commit *object.Commit) error {
 	msg, err := commitparser.Parse(commit.Message)
+
+	// Prechecks that only warn or are suppressed are reported with the other rules.
+	errs := l.check(l.Prechecks, msg, commit)
+	if slices.ContainsFunc(errs, linter.Failed) {
+		return errors.Join(errs...)
+	}
+
 	if err != nil {
 		if l.Filters.Filter(msg, commit, err) == nil {
-			return nil
+			return errors.Join(errs...)
 		}
 
 		var parseError commitparser.ParseError
 		if errors.As(err, &parseError) {
-			return linter.LintError{
-				Err:  parseError,
-				Hash: commit.Hash,
-				Pos:  parseError.Pos,
+			err = linter.LintError{
+				Err:      parseError,
+				Hash:     commit.Hash,
+				Pos:      parseError.Pos,
+				Rule:     RuleHeaderFormat,
+				Severity: l.Severities[RuleHeaderFormat],
+				Commit:   commit,
 			}
 		}
 
-		return err
+		if len(errs) == 0 {
+			return err
+		}
+
+		return errors.Join(append(errs, err)...)
 	}
 
-	if err = l.Rules.Validate(msg, commit); err != nil {
-		return linter.LintError{
-			Err:  err,
-			Hash: commit.Hash,
+	return errors.Join(append(errs, l.check(l.Rules, msg, commit)...)...)
+}
+
+// check checks the rules and returns a [linter.LintError] for each rule that failed.
+func (l Linter) check(rules Rules, msg commitparser.CommitMessage, commit *object.Commit) []error {
+	ignored := ignoredRules(msg)
+
+	var errs []error
+
+	for _, rule := range rules {
+		err := rule(msg, commit)
+		if err == nil {
+			continue
+		}
+
+		lintError := l.lintError(commit, err)
+
+		if _, found := ignored[lintError.Rule]; found && lintError.Rule != "" {
+			if l.suppressible(lintError.Rule) {
+				lintError.Suppressed = true
+			} else {
+				lintError.Err = fmt.Errorf("%w (%w by %s)", lintError.Err, ErrNotSuppressible, commitparser.TrailerKeyLintIgnore)
+			}
 		}
+
+		errs = append(errs, lintError)
 	}
 
-	return nil
+	return errs
+}
+
+// ignoredRules returns the IDs of the rules that the commit message's Lint-Ignore trailers list.
+func ignoredRules(msg commitparser.CommitMessage) map[string]struct{} {
+	ignored := make(map[string]struct{})
+
+	for _, value := range msg.Trailers[commitparser.TrailerKeyLintIgnore] {
+		for id := range strings.SplitSeq(value, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				ignored[id] = struct{}{}
+			}
+		}
+	}
+
+	return ignored
+}
+
+func (l Linter) suppressible(id string) bool {
+	return slices.Contains(l.Suppressible, AnyRule) || slices.Contains(l.Suppressible, id)
+}
+
+func (l Linter) lintError(commit *object.Commit, err error) linter.LintError {
+	var ruleError RuleError
+	errors.As(err, &ruleError)
+
+	var posError PosError
+	errors.As(err, &posError)
+
+	var suggestionError SuggestionError
+	errors.As(err, &suggestionError)
+
+	return linter.LintError{
+		Err:        err,
+		Hash:       commit.Hash,
+		Pos:        posError.Pos,
+		Rule:       ruleError.Rule,
+		Severity:   l.Severities[ruleError.Rule],
+		Suggestion: suggestionError.Suggestion,
+		Commit:     commit,
+	}
 }
@@ -8,12 +8,14 @@
 package commitlinter_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/google/go-cmp/cmp"
 
 	"codeberg.org/somebadcode/commit-tool/commitlinter"
 	"codeberg.org/somebadcode/commit-tool/commitlinter/conventionalcommits"
@@ -140,6 +142,18 @@ func TestLinter_Lint(t *testing.T) {
 		{
 			repoOps: []repobuilder.OperationFunc{
 				repobuilder.Commit("Merge 'foo' into 'bar'", commitOpts),
+				repobuilder.Commit("Merge branch 'fix-foo' into main", commitOpts),
+				repobuilder.Commit("Merge pull request #42 from gopher/fix-foo", commitOpts),
+				repobuilder.Commit("chore(foo): fixed formatting", commitOpts),
+			},
+			fields: fields{
+				Rev:    "HEAD",
+				Linter: &commitlinter.Linter{},
+			},
+		},
+		{
+			repoOps: []repobuilder.OperationFunc{
+				repobuilder.Commit("Merge the foo branch", commitOpts),
 				repobuilder.Commit("chore(foo): fixed formatting", commitOpts),
 			},
 			fields: fields{
@@ -227,3 +241,209 @@ func TestLinter_Lint(t *testing.T) {
 		})
 	}
 }
+
+func TestLinter_Lint_suppression(t *testing.T) {
+	t.Parallel()
+
+	type violation struct {
+		Rule         string
+		Suppressed   bool
+		Suppressible bool
+	}
+
+	rules := commitlinter.Rules{
+		commitlinter.NamedRule("subject-case", conventionalcommits.VerifySubjectCase),
+		commitlinter.NamedRule("subject-full-stop", conventionalcommits.VerifySubjectFullStop),
+	}
+
+	tests := []struct {
+		name         string
+		message      string
+		suppressible []string
+		want         []violation
+		wantFailed   bool
+	}{
+		{
+			name:         "suppressed",
+			message:      "feat: Add foo\n\nLint-Ignore: subject-case",
+			suppressible: []string{commitlinter.AnyRule},
+			want: []violation{
+				{Rule: "subject-case", Suppressed: true, Suppressible: true},
+			},
+		},
+		{
+			name:         "suppressed_with_body",
+			message:      "feat: Add foo.\n\nVendored from upstream.\n\nLint-Ignore: subject-case, subject-full-stop",
+			suppressible: []string{"subject-case", "subject-full-stop"},
+			want: []violation{
+				{Rule: "subject-case", Suppressed: true, Suppressible: true},
+				{Rule: "subject-full-stop", Suppressed: true, Suppressible: true},
+			},
+		},
+		{
+			name:         "other_rule_suppressed",
+			message:      "feat: Add foo.\n\nLint-Ignore: subject-full-stop",
+			suppressible: []string{commitlinter.AnyRule},
+			want: []violation{
+				{Rule: "subject-case", Suppressible: true},
+				{Rule: "subject-full-stop", Suppressed: true, Suppressible: true},
+			},
+			wantFailed: true,
+		},
+		{
+			name:         "not_suppressible",
+			message:      "feat: Add foo\n\nLint-Ignore: subject-case",
+			suppressible: []string{"subject-full-stop"},
+			want: []violation{
+				{Rule: "subject-case"},
+			},
+			wantFailed: true,
+		},
+		{
+			name:    "nothing_suppressible",
+			message: "feat: Add foo\n\nLint-Ignore: subject-case",
+			want: []violation{
+				{Rule: "subject-case"},
+			},
+			wantFailed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := commitlinter.Linter{
+				Rules:        rules,
+				Suppressible: tt.suppressible,
+			}
+
+			err := l.Lint(&object.Commit{Message: tt.message})
+
+			var got []violation
+
+			for _, lintError := range linter.LintErrors(err) {
+				got = append(got, violation{
+					Rule:         lintError.Rule,
+					Suppressed:   lintError.Suppressed,
+					Suppressible: !errors.Is(lintError, commitlinter.ErrNotSuppressible),
+				})
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Lint() mismatch (-want +got):\n%s", diff)
+			}
+
+			if failed := linter.Failed(err); failed != tt.wantFailed {
+				t.Errorf("Failed() = %v, want %v", failed, tt.wantFailed)
+			}
+		})
+	}
+}
+
+func TestLinter_Lint_prechecks(t *testing.T) {
+	t.Parallel()
+
+	type violation struct {
+		Rule       string
+		Suppressed bool
+	}
+
+	l := commitlinter.Linter{
+		Prechecks: commitlinter.Rules{
+			commitlinter.NamedRule("forbidden-pattern", conventionalcommits.ForbiddenPattern(conventionalcommits.DefaultForbiddenPatterns)),
+		},
+		Rules: commitlinter.Rules{
+			commitlinter.NamedRule("subject-case", conventionalcommits.VerifySubjectCase),
+		},
+		Suppressible: []string{commitlinter.AnyRule},
+	}
+
+	hash := plumbing.NewHash("8f1c0d6a2b5e4f3a9c7d1e0b2a4c6e8f0a1b3c5d")
+
+	tests := []struct {
+		name    string
+		message string
+		hash    plumbing.Hash
+		want    []violation
+	}{
+		{
+			name:    "unparsable",
+			message: "WIP",
+			hash:    hash,
+			want: []violation{
+				{Rule: "forbidden-pattern"},
+			},
+		},
+		{
+			name:    "fixup",
+			message: "fixup! feat: Add foo",
+			hash:    hash,
+			want: []violation{
+				{Rule: "forbidden-pattern"},
+			},
+		},
+		{
+			name:    "suppressed",
+			message: "wip: Add foo\n\nLint-Ignore: forbidden-pattern",
+			hash:    hash,
+			want: []violation{
+				{Rule: "forbidden-pattern", Suppressed: true},
+				{Rule: "subject-case"},
+			},
+		},
+		{
+			name:    "passed",
+			message: "feat: Add foo",
+			hash:    hash,
+			want: []violation{
+				{Rule: "subject-case"},
+			},
+		},
+		{
+			// The pending message of the commit-msg hook is rejected for the pattern, not the header format.
+			name:    "pending_wip",
+			message: "WIP",
+			want: []violation{
+				{Rule: "forbidden-pattern"},
+			},
+		},
+		{
+			// git commit --fixup works in the commit-msg hook.
+			name:    "pending_fixup",
+			message: "fixup! feat: add foo",
+		},
+		{
+			name:    "header_format",
+			message: "add foo",
+			hash:    hash,
+			want: []violation{
+				{Rule: commitlinter.RuleHeaderFormat},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := l.Lint(&object.Commit{
+				Hash:    tt.hash,
+				Message: tt.message,
+			})
+
+			var got []violation
+
+			for _, lintError := range linter.LintErrors(err) {
+				got = append(got, violation{
+					Rule:       lintError.Rule,
+					Suppressed: lintError.Suppressed,
+				})
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Lint() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package commitlinter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+
+	"codeberg.org/somebadcode/commit-tool/linter"
+	"codeberg.org/somebadcode/commit-tool/mailmap"
+)
+
+var (
+	ErrUnknownRule  = errors.New("unknown rule")
+	ErrNoRepository = errors.New("rule needs a repository")
+)
+
+// RuleDefinition defines a rule that can be looked up by its ID, so that it can be configured and explained.
+type RuleDefinition struct {
+	// ID is the stable identifier of the rule, e.g. "subject-case".
+	ID string
+	// Title is a short summary of the rule.
+	Title string
+	// Description explains what the rule checks and why.
+	Description string
+	// Help explains how to write a commit message that adheres to the rule.
+	Help string
+	// Severity is the severity of the rule unless configured otherwise.
+	Severity linter.Severity
+	// Disabled rules are only used if they are enabled by configuration.
+	Disabled bool
+	// Options are the options that the rule can be configured with.
+	Options []OptionDefinition
+	// Precheck rules are checked before the message is parsed, see [Linter.Prechecks].
+	Precheck bool
+	// New creates the rule. It's nil for rules that are enforced by the linter itself, like [RuleHeaderFormat].
+	New func(config RuleConfig) (RuleFunc, error)
+}
+
+// OptionDefinition describes an option of a rule.
+type OptionDefinition struct {
+	Name        string
+	Type        string
+	Default     string
+	Description string
+}
+
+// RuleConfig is the configuration that a rule is created with.
+type RuleConfig struct {
+	// Types are the allowed commit types, the rule decides what an empty list means.
+	Types []string
+	// Scopes are the allowed commit scopes, the rule decides what an empty list means.
+	Scopes []string
+	// ScopePaths maps scopes to the paths that they cover, as gitignore-style patterns.
+	ScopePaths map[string][]string
+	// TypePaths maps types to the paths that they cover, as gitignore-style patterns.
+	TypePaths map[string][]string
+	// Mailmap maps the identities of commits to canonical ones, it may be nil.
+	Mailmap *mailmap.Mailmap
+	// Repository is the repository of the commits, it may be nil.
+	Repository *git.Repository
+	// DecodeOptions decodes the options of the rule into v, which must be a pointer. Options that are not set keep the
+	// value that v already has. It may be nil if the rule has no options configured.
+	DecodeOptions func(v any) error
+}
+
+// Decode decodes the options of the rule into v, see [RuleConfig.DecodeOptions].
+func (config RuleConfig) Decode(v any) error {
+	if config.DecodeOptions == nil {
+		return nil
+	}
+
+	return config.DecodeOptions(v)
+}
+
+// Descriptor describes the rule for reports.
+func (def RuleDefinition) Descriptor() linter.RuleDescriptor {
+	return linter.RuleDescriptor{
+		ID:          def.ID,
+		Title:       def.Title,
+		Description: def.Description,
+		Help:        def.Help,
+		Severity:    def.Severity,
+	}
+}
+
+// Registry is a list of rule definitions with unique IDs.
+type Registry []RuleDefinition
+
+// HeaderFormat defines the rule that is enforced by parsing the commit message. It's always enabled.
+var HeaderFormat = RuleDefinition{
+	ID:    RuleHeaderFormat,
+	Title: "Conventional commit header",
+	Description: "The first line of the commit message, the header, must be a conventional commit header: a type, an " +
+		"optional scope within parentheses, an optional exclamation mark for breaking changes, a colon and a space " +
+		"followed by the subject. The other rules depend on the parsed header, so this rule can't be disabled, but " +
+		"its severity can be changed.",
+	Help: "Start the commit message with a header like \"type(scope): subject\", where the scope is optional.",
+}
+
+// Lookup returns the definition of the rule with the ID.
+func (registry Registry) Lookup(id string) (RuleDefinition, error) {
+	for _, def := range registry {
+		if def.ID == id {
+			return def, nil
+		}
+	}
+
+	return RuleDefinition{}, fmt.Errorf("%w %q", ErrUnknownRule, id)
+}
+
+// Descriptors describes every rule in the registry.
+func (registry Registry) Descriptors() []linter.RuleDescriptor {
+	descriptors := make([]linter.RuleDescriptor, 0, len(registry))
+
+	for _, def := range registry {
+		descriptors = append(descriptors, def.Descriptor())
+	}
+
+	return descriptors
+}
@@ -0,0 +1,99 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package commitlinter_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitlinter/conventionalcommits"
+	"codeberg.org/somebadcode/commit-tool/linter"
+)
+
+func TestRegistry_Lookup(t *testing.T) {
+	t.Parallel()
+
+	registry := commitlinter.Registry{
+		commitlinter.HeaderFormat,
+		{
+			ID:       "subject-case",
+			Title:    "Lower case subject",
+			Severity: linter.SeverityWarning,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		id      string
+		want    linter.RuleDescriptor
+		wantErr error
+	}{
+		{
+			name: "found",
+			id:   "subject-case",
+			want: linter.RuleDescriptor{
+				ID:       "subject-case",
+				Title:    "Lower case subject",
+				Severity: linter.SeverityWarning,
+			},
+		},
+		{
+			name:    "unknown",
+			id:      "subject-kase",
+			wantErr: commitlinter.ErrUnknownRule,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			def, err := registry.Lookup(tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Lookup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, def.Descriptor()); err == nil && diff != "" {
+				t.Errorf("Lookup() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConventionalCommitsRegistry(t *testing.T) {
+	t.Parallel()
+
+	seen := make(map[string]bool)
+
+	for _, def := range conventionalcommits.Registry {
+		if seen[def.ID] {
+			t.Errorf("rule %q is defined more than once", def.ID)
+		}
+
+		seen[def.ID] = true
+
+		if def.Title == "" || def.Description == "" || def.Help == "" {
+			t.Errorf("rule %q must have a title, a description and help", def.ID)
+		}
+
+		if def.New == nil && def.ID != commitlinter.RuleHeaderFormat {
+			t.Errorf("rule %q can't be created", def.ID)
+		}
+
+		if def.New == nil {
+			continue
+		}
+
+		if _, err := def.New(commitlinter.RuleConfig{}); err != nil {
+			t.Errorf("rule %q: New() error = %v", def.ID, err)
+		}
+	}
+}
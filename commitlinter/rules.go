@@ -9,6 +9,7 @@ package commitlinter
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/go-git/go-git/v5/plumbing/object"
 
@@ -23,8 +24,141 @@ var (
 	ErrInvalidSubject   = errors.New("invalid subject in commit message")
 	ErrInvalidCharacter = errors.New("invalid character in commit message")
 	ErrInvalidType      = errors.New("invalid type in commit message")
+	ErrInvalidScope     = errors.New("invalid scope in commit message")
+	ErrNotSuppressible  = errors.New("rule can't be suppressed")
+	ErrLineTooLong      = errors.New("line in commit message is too long")
+	ErrHeaderTooShort   = errors.New("header of commit message is too short")
+	ErrNoBlankLine      = errors.New("no blank line between sections of commit message")
+	ErrScopePath        = errors.New("changed paths don't match the scope of commit message")
+	ErrTypePath         = errors.New("changed paths don't match the type of commit message")
+	ErrNotSignedOff     = errors.New("commit message is not signed off")
+	ErrDuplicateTrailer = errors.New("duplicate trailer in commit message")
+	ErrUnknownIdentity  = errors.New("unknown identity in commit message")
+	ErrNoReference      = errors.New("commit message references no issue")
+	ErrNotImperative    = errors.New("subject of commit message is not in the imperative mood")
+	ErrForbiddenMessage = errors.New("commit message is not meant to be merged")
+	ErrRevertedCommit   = errors.New("bad reverted commit in commit message")
 )
 
+// RuleError is an error returned by a rule that has an ID, see [NamedRule].
+type RuleError struct {
+	Rule string
+	Err  error
+}
+
+func (err RuleError) Error() string {
+	return err.Err.Error()
+}
+
+func (err RuleError) Unwrap() error {
+	return err.Err
+}
+
+// PosError is an error at a byte offset into the commit message, it allows reports to point at the error.
+type PosError struct {
+	Pos int
+	Err error
+}
+
+func (err PosError) Error() string {
+	return err.Err.Error()
+}
+
+func (err PosError) Unwrap() error {
+	return err.Err
+}
+
+// ErrorAt returns an error at the byte offset into the commit message.
+func ErrorAt(pos int, err error) error {
+	return PosError{
+		Pos: pos,
+		Err: err,
+	}
+}
+
+// SuggestionError is an error with a suggestion of how to fix it, e.g. `did you mean "docs:"?`, that reports show with
+// the error.
+type SuggestionError struct {
+	Suggestion string
+	Err        error
+}
+
+func (err SuggestionError) Error() string {
+	return err.Err.Error()
+}
+
+func (err SuggestionError) Unwrap() error {
+	return err.Err
+}
+
+// WithSuggestion returns the error with a suggestion of how to fix it.
+func WithSuggestion(err error, suggestion string) error {
+	return SuggestionError{
+		Suggestion: suggestion,
+		Err:        err,
+	}
+}
+
+// ScopePos returns the byte offset of the scope in the commit message.
+func ScopePos(msg commitparser.CommitMessage) int {
+	// The type is at the start of the message and the scope follows the opening parenthesis.
+	return len(msg.Type) + 1
+}
+
+// SubjectPos returns the byte offset of the subject in the commit's message.
+func SubjectPos(msg commitparser.CommitMessage, commit *object.Commit) int {
+	if commit == nil {
+		return 0
+	}
+
+	// The subject is the rest of the first line, or quoted by it like in git's `Revert "feat: add foo"`.
+	header, _, _ := strings.Cut(commit.Message, "\n")
+
+	if i := strings.LastIndex(header, msg.Subject); i != -1 {
+		return i
+	}
+
+	return max(len(header)-len(msg.Subject), 0)
+}
+
+// TrailerPos returns the byte offset of the first trailer in the commit's message with the key, ignoring case, whose
+// value starts with the first line of the value. It returns the offset of the footer if there's no such trailer, and 0
+// if there's no footer.
+func TrailerPos(msg commitparser.CommitMessage, commit *object.Commit, key string, value string) int {
+	if commit == nil || msg.Separation.FooterPos > len(commit.Message) {
+		return 0
+	}
+
+	value, _, _ = strings.Cut(value, "\n")
+
+	pos := msg.Separation.FooterPos
+
+	for line := range strings.Lines(commit.Message[pos:]) {
+		k, v, found := strings.Cut(line, ":")
+		if found && strings.EqualFold(k, key) && strings.HasPrefix(strings.TrimSpace(v), value) {
+			return pos
+		}
+
+		pos += len(line)
+	}
+
+	return msg.Separation.FooterPos
+}
+
+// NamedRule attributes the errors of the rule to the rule with the ID, so that reports can tell which rule failed.
+func NamedRule(id string, rule RuleFunc) RuleFunc {
+	return func(message commitparser.CommitMessage, commit *object.Commit) error {
+		if err := rule(message, commit); err != nil {
+			return RuleError{
+				Rule: id,
+				Err:  err,
+			}
+		}
+
+		return nil
+	}
+}
+
 func (rules Rules) Validate(message commitparser.CommitMessage, commit *object.Commit) error {
 	for _, rule := range rules {
 		if err := rule(message, commit); err != nil {
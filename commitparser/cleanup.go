@@ -0,0 +1,81 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package commitparser
+
+import (
+	"strings"
+)
+
+const (
+	// Scissors is the line, following the comment character and a space, that marks the end of a commit message when
+	// committing with `git commit --verbose` or `--cleanup=scissors`.
+	Scissors = "------------------------ >8 ------------------------"
+
+	// DefaultCommentString is the string that starts a comment line unless git has been configured otherwise.
+	DefaultCommentString = "#"
+)
+
+// Cleanup removes everything below the scissors line, comment lines, trailing whitespace and superfluous blank lines
+// from a message the same way that git does before it stores the commit message.
+func Cleanup(message string, commentString string) string {
+	if commentString == "" {
+		commentString = DefaultCommentString
+	}
+
+	scissors := commentString + " " + Scissors
+
+	var sb strings.Builder
+
+	blank := 0
+
+	for line := range strings.Lines(message) {
+		line = strings.TrimRightFunc(line, isSpace)
+
+		if line == scissors {
+			break
+		}
+
+		if strings.HasPrefix(line, commentString) {
+			continue
+		}
+
+		if line == "" {
+			blank++
+			continue
+		}
+
+		// Blank lines are only kept if they separate two lines with content.
+		if blank > 0 && sb.Len() > 0 {
+			sb.WriteByte('\n')
+		}
+
+		blank = 0
+
+		sb.WriteString(line)
+		sb.WriteByte('\n')
+	}
+
+	return sb.String()
+}
+
+// DetectCommentString finds the comment string used by a message that was written with git's `core.commentChar` set to
+// `auto`, using the scissors line. It returns [DefaultCommentString] if there's no scissors line.
+func DetectCommentString(message string) string {
+	for line := range strings.Lines(message) {
+		prefix, found := strings.CutSuffix(strings.TrimRightFunc(line, isSpace), " "+Scissors)
+		if found && prefix != "" {
+			return prefix
+		}
+	}
+
+	return DefaultCommentString
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '\v' || r == '\f'
+}
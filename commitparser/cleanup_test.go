@@ -0,0 +1,101 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package commitparser
+
+import (
+	"testing"
+)
+
+func TestCleanup(t *testing.T) {
+	type args struct {
+		message       string
+		commentString string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "comments",
+			args: args{
+				message: "feat: add foo\n# Please enter the commit message for your changes.\n#\n",
+			},
+			want: "feat: add foo\n",
+		},
+		{
+			name: "blank_lines",
+			args: args{
+				message: "\n\nfeat: add foo  \n\n\n\nAdded foo.\t\n\n",
+			},
+			want: "feat: add foo\n\nAdded foo.\n",
+		},
+		{
+			name: "scissors",
+			args: args{
+				message: "fix: bar\n\nFixed bar.\n# ------------------------ >8 ------------------------\n# Do not modify or remove the line above.\ndiff --git a/bar b/bar\n",
+			},
+			want: "fix: bar\n\nFixed bar.\n",
+		},
+		{
+			name: "comment_char",
+			args: args{
+				message:       "fix: bar\n\n#1 is fixed.\n; comment\n",
+				commentString: ";",
+			},
+			want: "fix: bar\n\n#1 is fixed.\n",
+		},
+		{
+			name: "comment_between_paragraphs",
+			args: args{
+				message: "fix: bar\n\n# comment\n\nFixed bar.",
+			},
+			want: "fix: bar\n\nFixed bar.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := Cleanup(tt.args.message, tt.args.commentString); got != tt.want {
+				t.Errorf("Cleanup() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectCommentString(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{
+			name:    "scissors",
+			message: "fix: bar\n; ------------------------ >8 ------------------------\ndiff\n",
+			want:    ";",
+		},
+		{
+			name:    "no_scissors",
+			message: "fix: bar\n; comment\n",
+			want:    DefaultCommentString,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := DetectCommentString(tt.message); got != tt.want {
+				t.Errorf("DetectCommentString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -16,4 +16,37 @@ type CommitMessage struct {
 	Breaking bool
 	Revert   bool
 	Merge    bool
+	// Autosquash is the command of a message that git rebase --autosquash squashes into an earlier commit,
+	// [AutosquashFixup], [AutosquashSquash] or [AutosquashAmend]. The rest of the header is parsed as the header of the
+	// earlier commit.
+	Autosquash string
+	// Reverted is the header of the commit that a revert reverts, if the revert quotes a conventional commit header, e.g.
+	// in git's `Revert "feat: add foo"` or in "revert: feat: add foo".
+	Reverted *CommitMessage
+	// RevertedHashes are the hashes of the commits that a revert reverts, from the "This reverts commit <hash>." lines
+	// that git adds to the body, or from Refs trailers as Conventional Commits suggests.
+	RevertedHashes []string
+	// MergeSource is the branch, tag or commit that a merge commit merges, e.g. x in git's "Merge branch 'x' into main".
+	MergeSource string
+	// MergeTarget is the branch that a merge commit merges into. It's empty if the header doesn't tell, which git does
+	// when merging into the default branch.
+	MergeTarget string
+	// Separation tells how the sections of the message were separated.
+	Separation Separation
+}
+
+// Separation tells where the body and the footer of a commit message are and how many blank lines separate them from
+// what precedes them. Tools like git expect a single blank line, e.g. `git log --oneline` shows a body that directly
+// follows the header as part of the subject.
+type Separation struct {
+	// BodyPos is the byte offset of the body into the message, it's 0 if there's no body.
+	BodyPos int
+	// BodyBlankLines is the number of blank lines between the header and the body.
+	BodyBlankLines int
+	// FooterPos is the byte offset of the footer into the message, it's 0 if there's no footer. The footer is the
+	// trailers, or lines at the end of the body that look like trailers but aren't parsed as such since no blank line
+	// separates them from the body.
+	FooterPos int
+	// FooterBlankLines is the number of blank lines between the footer and what precedes it.
+	FooterBlankLines int
 }
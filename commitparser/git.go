@@ -0,0 +1,100 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package commitparser
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Types of the headers that git writes for reverts and merges, see [CommitMessage.Revert] and [CommitMessage.Merge].
+const (
+	TypeRevert = "revert"
+	TypeMerge  = "merge"
+)
+
+var (
+	// revertPattern matches the header of git revert, e.g. `Revert "feat: add foo"`.
+	revertPattern = regexp.MustCompile(`^Revert "(.+)"$`)
+	// reapplyPattern matches the header of git revert of a revert since git 2.43, e.g. `Reapply "feat: add foo"`.
+	reapplyPattern = regexp.MustCompile(`^Reapply "(.+)"$`)
+	// revertedPattern matches the line of the body of git revert that names the reverted commit.
+	revertedPattern = regexp.MustCompile(`^This reverts commit ([0-9a-f]{7,64})\b`)
+	// hashPattern matches abbreviated and full commit hashes.
+	hashPattern = regexp.MustCompile(`^[0-9a-f]{7,64}$`)
+	// mergePattern matches the headers of git merge, like "Merge branch 'x' into y", "Merge branch 'x' of url",
+	// "Merge remote-tracking branch 'origin/x'", "Merge tag 'v1.0.0'" and "Merge commit 'abc1234'".
+	mergePattern = regexp.MustCompile(`^Merge (?:(?:remote-tracking )?branch |tag |commit )?'([^']+)'(?: of \S+)?(?: into '?([^'\s]+)'?)?$`)
+	// pullRequestPattern matches the header of pull requests merged on GitHub, e.g. "Merge pull request #1 from a/b".
+	pullRequestPattern = regexp.MustCompile(`^Merge pull request #[0-9]+ from (\S+)$`)
+)
+
+// parseGitHeader parses the headers that git writes for reverts and merges, which are not conventional commit headers.
+// It reports if it parsed the header, in which case the body follows.
+func (p *parser) parseGitHeader() bool {
+	header, _, _ := strings.Cut(p.remains(), "\n")
+
+	if match := revertPattern.FindStringSubmatch(header); match != nil {
+		p.commit.Type = TypeRevert
+		p.commit.Subject = match[1]
+	} else if match = reapplyPattern.FindStringSubmatch(header); match != nil {
+		// A reapply reverts a revert, so the subject is the header of the revert, like it is in older versions of git
+		// that write `Revert "Revert "feat: add foo""`.
+		p.commit.Type = TypeRevert
+		p.commit.Subject = `Revert "` + match[1] + `"`
+	} else if match = mergePattern.FindStringSubmatch(header); match != nil {
+		p.commit.Type = TypeMerge
+		p.commit.Subject = strings.TrimPrefix(header, "Merge ")
+		p.commit.MergeSource = match[1]
+		p.commit.MergeTarget = match[2]
+	} else if match = pullRequestPattern.FindStringSubmatch(header); match != nil {
+		p.commit.Type = TypeMerge
+		p.commit.Subject = strings.TrimPrefix(header, "Merge ")
+		p.commit.MergeSource = match[1]
+	} else {
+		return false
+	}
+
+	p.pos += len(header)
+	p.skip()
+
+	return true
+}
+
+// parseReverted parses which commits a revert reverts.
+func (p *parser) parseReverted() {
+	// The subject of a revert is the header of the reverted commit if it's quoted like git does, and often otherwise.
+	if reverted, err := Parse(p.commit.Subject); err == nil {
+		p.commit.Reverted = &reverted
+	}
+
+	for line := range strings.Lines(p.commit.Body) {
+		if match := revertedPattern.FindStringSubmatch(line); match != nil {
+			p.commit.RevertedHashes = append(p.commit.RevertedHashes, match[1])
+		}
+	}
+
+	for key, values := range p.commit.Trailers {
+		if !strings.EqualFold(key, "Refs") {
+			continue
+		}
+
+		for _, value := range values {
+			for _, hash := range strings.FieldsFunc(value, isListSeparator) {
+				if hashPattern.MatchString(hash) && !slices.Contains(p.commit.RevertedHashes, hash) {
+					p.commit.RevertedHashes = append(p.commit.RevertedHashes, hash)
+				}
+			}
+		}
+	}
+}
+
+func isListSeparator(r rune) bool {
+	return r == ',' || r == ' ' || r == '\n'
+}
@@ -0,0 +1,119 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package commitparser
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidIdentity = errors.New("invalid identity")
+
+const (
+	TrailerKeyCoAuthoredBy = "Co-authored-by"
+	TrailerKeyReviewedBy   = "Reviewed-by"
+	TrailerKeyAckedBy      = "Acked-by"
+	TrailerKeyTestedBy     = "Tested-by"
+	TrailerKeyReportedBy   = "Reported-by"
+	TrailerKeySuggestedBy  = "Suggested-by"
+)
+
+// IdentityTrailerKeys are the keys of the trailers whose values are identities, see [CommitMessage.Identities].
+var IdentityTrailerKeys = []string{
+	TrailerKeySignedOffBy,
+	TrailerKeyCoAuthoredBy,
+	TrailerKeyReviewedBy,
+	TrailerKeyAckedBy,
+	TrailerKeyTestedBy,
+	TrailerKeyReportedBy,
+	TrailerKeySuggestedBy,
+}
+
+// Identity is the name and email address of a person, as in "Jane Doe <jane@example.com>".
+type Identity struct {
+	Name  string
+	Email string
+}
+
+// String returns the identity as in a trailer, e.g. "Jane Doe <jane@example.com>".
+func (id Identity) String() string {
+	return id.Name + " <" + id.Email + ">"
+}
+
+// ParseIdentity parses a name followed by an email address in angle brackets, e.g. the value of a Co-authored-by
+// trailer. The name must not be empty and the email address must have a local part and a domain.
+func ParseIdentity(value string) (Identity, error) {
+	name, rest, found := strings.Cut(strings.TrimSpace(value), "<")
+	if !found || !strings.HasSuffix(rest, ">") {
+		return Identity{}, fmt.Errorf("expected a name and an email address in angle brackets: %w", ErrInvalidIdentity)
+	}
+
+	id := Identity{
+		Name:  strings.TrimSpace(name),
+		Email: strings.TrimSuffix(rest, ">"),
+	}
+
+	if id.Name == "" {
+		return Identity{}, fmt.Errorf("name is empty: %w", ErrInvalidIdentity)
+	}
+
+	local, domain, found := strings.Cut(id.Email, "@")
+	if !found || local == "" || domain == "" || strings.ContainsAny(id.Email, "<>") ||
+		strings.ContainsRune(domain, '@') || strings.ContainsFunc(id.Email, unicode.IsSpace) {
+		return Identity{}, fmt.Errorf("email address %q is malformed: %w", id.Email, ErrInvalidIdentity)
+	}
+
+	return id, nil
+}
+
+// IdentityTrailerKey returns the key of [IdentityTrailerKeys] that the trailer key is, ignoring case as git does, e.g.
+// Co-authored-by for Co-Authored-By.
+func IdentityTrailerKey(key string) (string, bool) {
+	for _, k := range IdentityTrailerKeys {
+		if strings.EqualFold(k, key) {
+			return k, true
+		}
+	}
+
+	return "", false
+}
+
+// Identities returns the identities of the trailers with [IdentityTrailerKeys], by key. Values that aren't identities
+// are left out, see [ParseIdentity].
+func (msg CommitMessage) Identities() map[string][]Identity {
+	var identities map[string][]Identity
+
+	// Trailer keys that only differ in case are merged, in a stable order.
+	for _, key := range slices.Sorted(maps.Keys(msg.Trailers)) {
+		values := msg.Trailers[key]
+
+		key, ok := IdentityTrailerKey(key)
+		if !ok {
+			continue
+		}
+
+		for _, value := range values {
+			id, err := ParseIdentity(value)
+			if err != nil {
+				continue
+			}
+
+			if identities == nil {
+				identities = make(map[string][]Identity)
+			}
+
+			identities[key] = append(identities[key], id)
+		}
+	}
+
+	return identities
+}
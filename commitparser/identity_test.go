@@ -0,0 +1,112 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package commitparser
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseIdentity(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    Identity
+		wantErr error
+	}{
+		{
+			name:  "identity",
+			value: "Jane Doe <jane@example.com>",
+			want:  Identity{Name: "Jane Doe", Email: "jane@example.com"},
+		},
+		{
+			name:  "white_space",
+			value: "  J. Doe   <jane@example.com> ",
+			want:  Identity{Name: "J. Doe", Email: "jane@example.com"},
+		},
+		{
+			name:    "email_only",
+			value:   "jane@example.com",
+			wantErr: ErrInvalidIdentity,
+		},
+		{
+			name:    "no_name",
+			value:   "<jane@example.com>",
+			wantErr: ErrInvalidIdentity,
+		},
+		{
+			name:    "unclosed",
+			value:   "Jane Doe <jane@example.com",
+			wantErr: ErrInvalidIdentity,
+		},
+		{
+			name:    "no_domain",
+			value:   "Jane Doe <jane@>",
+			wantErr: ErrInvalidIdentity,
+		},
+		{
+			name:    "no_at",
+			value:   "Jane Doe <jane>",
+			wantErr: ErrInvalidIdentity,
+		},
+		{
+			name:    "space_in_email",
+			value:   "Jane Doe <jane doe@example.com>",
+			wantErr: ErrInvalidIdentity,
+		},
+		{
+			name:    "two_emails",
+			value:   "Jane Doe <jane@example.com> <doe@example.com>",
+			wantErr: ErrInvalidIdentity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseIdentity(tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseIdentity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseIdentity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitMessage_Identities(t *testing.T) {
+	t.Parallel()
+
+	msg, err := Parse("feat: add foo\n\n" +
+		"Co-authored-by: Jane Doe <jane@example.com>\n" +
+		"Co-Authored-By: Joe Bloggs <joe@example.com>\n" +
+		"Reviewed-by: nobody\n" +
+		"Refs: ABC-123\n" +
+		"Signed-off-by: Gopher <gopher@example.com>\n")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := map[string][]Identity{
+		TrailerKeyCoAuthoredBy: {
+			{Name: "Joe Bloggs", Email: "joe@example.com"},
+			{Name: "Jane Doe", Email: "jane@example.com"},
+		},
+		TrailerKeySignedOffBy: {
+			{Name: "Gopher", Email: "gopher@example.com"},
+		},
+	}
+
+	if got := msg.Identities(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Identities() = %v, want %v", got, want)
+	}
+}
@@ -40,6 +40,19 @@ const (
 	TrailerKeyBreakingChangePrefix = "BREAKING"
 	TrailerKeyBreakingChange       = TrailerKeyBreakingChangePrefix + " CHANGE"
 	TrailerKeyBreakingChangeAlt    = TrailerKeyBreakingChangePrefix + "-" + TrailerKeyBreakingChange
+
+	// TrailerKeyLintIgnore is the key of the trailer that lists the IDs of the rules that the commit message suppresses.
+	TrailerKeyLintIgnore = "Lint-Ignore"
+
+	// TrailerKeySignedOffBy is the key of the trailer that certifies the Developer Certificate of Origin.
+	TrailerKeySignedOffBy = "Signed-off-by"
+)
+
+// Commands of messages that git rebase --autosquash squashes into earlier commits, see [CommitMessage.Autosquash].
+const (
+	AutosquashFixup  = "fixup"
+	AutosquashSquash = "squash"
+	AutosquashAmend  = "amend"
 )
 
 func Parse(message string) (CommitMessage, error) {
@@ -47,7 +60,14 @@ func Parse(message string) (CommitMessage, error) {
 }
 
 func (p *parser) parse() (CommitMessage, error) {
-	for state := parseType(p); state != nil; state = state(p) {
+	p.parseAutosquash()
+
+	start := parseType
+	if p.parseGitHeader() {
+		start = parseBody
+	}
+
+	for state := start(p); state != nil; state = state(p) {
 		// Simple state machine.
 	}
 
@@ -63,16 +83,42 @@ func (p *parser) parse() (CommitMessage, error) {
 		p.commit.Trailers = nil
 	}
 
+	if p.err == nil {
+		p.separate()
+	}
+
 	switch p.commit.Type {
-	case "revert":
+	case TypeRevert:
 		p.commit.Revert = true
-	case "merge":
+
+		if p.err == nil {
+			p.parseReverted()
+		}
+	case TypeMerge:
 		p.commit.Merge = true
 	}
 
 	return p.commit, p.err
 }
 
+// parseAutosquash skips the prefixes of autosquash commands, like "fixup! ", which may be repeated, e.g. by a fixup of
+// a fixup. The first command is the one that applies.
+func (p *parser) parseAutosquash() {
+	for {
+		command, _, found := strings.Cut(p.remains(), "! ")
+		if !found || (command != AutosquashFixup && command != AutosquashSquash && command != AutosquashAmend) {
+			return
+		}
+
+		if p.commit.Autosquash == "" {
+			p.commit.Autosquash = command
+		}
+
+		p.pos += len(command) + len("! ")
+		p.skip()
+	}
+}
+
 func failParsing(p *parser, err error) stateFunc {
 	p.err = ParseError{
 		err: err,
@@ -166,7 +212,13 @@ func parseBody(p *parser) stateFunc {
 	p.skip()
 
 	i := strings.LastIndex(p.remains(), "\n\n")
-	if i == -1 {
+	if i == -1 && strings.HasPrefix(p.remains(), "\n") {
+		// A single paragraph follows the header, it's the trailers unless it can't be parsed as such.
+		p.pos++
+		p.skip()
+
+		return parseTrailers
+	} else if i == -1 {
 		// No more paragraphs found. Using the remains for the message body and stopping.
 		p.commit.Body = strings.TrimSpace(p.remains())
 
@@ -198,8 +250,13 @@ func parseTrailers(p *parser) stateFunc {
 
 	if errors.Is(p.err, ErrInvalidTrailer) {
 		p.err = nil
-		p.commit.Body += "\n\n" + remains
 		p.commit.Trailers = nil
+
+		if p.commit.Body == "" {
+			p.commit.Body = strings.TrimSpace(remains)
+		} else {
+			p.commit.Body += "\n\n" + remains
+		}
 	}
 
 	// If there are no trailers, make sure the map is nil.
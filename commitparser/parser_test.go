@@ -31,11 +31,12 @@ var tests = []testCase{
 			message: "feat(woop): something\n\nAdded more features\n",
 		},
 		want: CommitMessage{
-			Type:     "feat",
-			Scope:    "woop",
-			Subject:  "something",
-			Body:     "Added more features",
-			Trailers: nil,
+			Type:       "feat",
+			Scope:      "woop",
+			Subject:    "something",
+			Body:       "Added more features",
+			Trailers:   nil,
+			Separation: Separation{BodyPos: 23, BodyBlankLines: 1},
 		},
 	},
 	{
@@ -44,10 +45,11 @@ var tests = []testCase{
 			message: "feat: something\n\nAdded more features\n",
 		},
 		want: CommitMessage{
-			Type:     "feat",
-			Subject:  "something",
-			Body:     "Added more features",
-			Trailers: nil,
+			Type:       "feat",
+			Subject:    "something",
+			Body:       "Added more features",
+			Trailers:   nil,
+			Separation: Separation{BodyPos: 17, BodyBlankLines: 1},
 		},
 	},
 	{
@@ -81,10 +83,11 @@ var tests = []testCase{
 			message: "feat!: refactored to support Y\n\nDid stuff!",
 		},
 		want: CommitMessage{
-			Type:     "feat",
-			Subject:  "refactored to support Y",
-			Breaking: true,
-			Body:     "Did stuff!",
+			Type:       "feat",
+			Subject:    "refactored to support Y",
+			Breaking:   true,
+			Body:       "Did stuff!",
+			Separation: Separation{BodyPos: 32, BodyBlankLines: 1},
 		},
 	},
 	{
@@ -93,11 +96,12 @@ var tests = []testCase{
 			message: "feat(cli)!: refactored to support Y\n\nDid stuff!",
 		},
 		want: CommitMessage{
-			Type:     "feat",
-			Subject:  "refactored to support Y",
-			Scope:    "cli",
-			Breaking: true,
-			Body:     "Did stuff!",
+			Type:       "feat",
+			Subject:    "refactored to support Y",
+			Scope:      "cli",
+			Breaking:   true,
+			Body:       "Did stuff!",
+			Separation: Separation{BodyPos: 37, BodyBlankLines: 1},
 		},
 	},
 	{
@@ -165,9 +169,10 @@ var tests = []testCase{
 			message: "feat: hej\n\nSomething fun",
 		},
 		want: CommitMessage{
-			Type:    "feat",
-			Subject: "hej",
-			Body:    "Something fun",
+			Type:       "feat",
+			Subject:    "hej",
+			Body:       "Something fun",
+			Separation: Separation{BodyPos: 11, BodyBlankLines: 1},
 		},
 	},
 	{
@@ -176,9 +181,10 @@ var tests = []testCase{
 			message: "feat: hallo\n\nSomething fun\n\nSecond paragraph",
 		},
 		want: CommitMessage{
-			Type:    "feat",
-			Subject: "hallo",
-			Body:    "Something fun\n\nSecond paragraph",
+			Type:       "feat",
+			Subject:    "hallo",
+			Body:       "Something fun\n\nSecond paragraph",
+			Separation: Separation{BodyPos: 13, BodyBlankLines: 1},
 		},
 	},
 	{
@@ -193,6 +199,7 @@ var tests = []testCase{
 			Trailers: map[string][]string{
 				"Ticket": {"ABC-4321"},
 			},
+			Separation: Separation{BodyPos: 10, BodyBlankLines: 1, FooterPos: 43, FooterBlankLines: 1},
 		},
 	},
 	{
@@ -208,17 +215,88 @@ var tests = []testCase{
 				"Ticket": {"ABC-4321"},
 				"Fix":    {"#12"},
 			},
+			Separation: Separation{BodyPos: 10, BodyBlankLines: 1, FooterPos: 43, FooterBlankLines: 1},
 		},
 	},
 	{
-		name: "not_a_trailer",
+		name: "trailers_without_body",
 		args: args{
-			message: "feat: oi\n\nSomething fun\n\nSecond paragraph\n\nTicket: ABC-4321\nMalformed commit",
+			message: "feat: oi\n\nLint-Ignore: subject-case, scope-enum\nTicket: ABC-4321\n",
 		},
 		want: CommitMessage{
 			Type:    "feat",
 			Subject: "oi",
-			Body:    "Something fun\n\nSecond paragraph\n\nTicket: ABC-4321\nMalformed commit",
+			Trailers: map[string][]string{
+				"Lint-Ignore": {"subject-case, scope-enum"},
+				"Ticket":      {"ABC-4321"},
+			},
+			Separation: Separation{FooterPos: 10, FooterBlankLines: 1},
+		},
+	},
+	{
+		name: "single_paragraph_not_trailers",
+		args: args{
+			message: "feat: oi\n\nTicket: ABC-4321\nMalformed commit\n",
+		},
+		want: CommitMessage{
+			Type:       "feat",
+			Subject:    "oi",
+			Body:       "Ticket: ABC-4321\nMalformed commit",
+			Separation: Separation{BodyPos: 10, BodyBlankLines: 1},
+		},
+	},
+	{
+		name: "body_without_blank_line",
+		args: args{
+			message: "feat: oi\nSomething fun\n",
+		},
+		want: CommitMessage{
+			Type:       "feat",
+			Subject:    "oi",
+			Body:       "Something fun",
+			Separation: Separation{BodyPos: 9},
+		},
+	},
+	{
+		name: "footer_without_blank_line",
+		args: args{
+			message: "feat: oi\n\nSomething fun\nSigned-off-by: Jane Doe <jane@example.com>\n",
+		},
+		want: CommitMessage{
+			Type:       "feat",
+			Subject:    "oi",
+			Body:       "Something fun\nSigned-off-by: Jane Doe <jane@example.com>",
+			Separation: Separation{BodyPos: 10, BodyBlankLines: 1, FooterPos: 24},
+		},
+	},
+	{
+		name: "extra_blank_lines",
+		args: args{
+			message: "feat: oi\n\n\nSomething fun\n\n\nTicket: ABC-4321\n",
+		},
+		want: CommitMessage{
+			Type:     "feat",
+			Subject:  "oi",
+			Body:     "Something fun",
+			Trailers: map[string][]string{"Ticket": {"ABC-4321"}},
+			Separation: Separation{
+				BodyPos:          11,
+				BodyBlankLines:   2,
+				FooterPos:        27,
+				FooterBlankLines: 2,
+			},
+		},
+	},
+	{
+		name: "not_a_trailer",
+		args: args{
+			message: "feat: oi\n\nSomething fun\n\nSecond paragraph\n\nTicket: ABC-4321\nMalformed commit",
+		},
+		want: CommitMessage{
+			Type:       "feat",
+			Subject:    "oi",
+			Body:       "Something fun\n\nSecond paragraph\n\nTicket: ABC-4321\nMalformed commit",
+			Separation: Separation{BodyPos: 10, BodyBlankLines: 1},
 		},
 	},
 	{
@@ -227,9 +305,10 @@ var tests = []testCase{
 			message: "feat: oi\n\nSomething fun\n\nSecond paragraph\n\nFix #900\nMalformed commit",
 		},
 		want: CommitMessage{
-			Type:    "feat",
-			Subject: "oi",
-			Body:    "Something fun\n\nSecond paragraph\n\nFix #900\nMalformed commit",
+			Type:       "feat",
+			Subject:    "oi",
+			Body:       "Something fun\n\nSecond paragraph\n\nFix #900\nMalformed commit",
+			Separation: Separation{BodyPos: 10, BodyBlankLines: 1},
 		},
 	},
 	{
@@ -238,9 +317,10 @@ var tests = []testCase{
 			message: "feat: oi\n\nSomething fun\n\nSecond paragraph\n\nReference to: abcdef1234\nMalformed commit",
 		},
 		want: CommitMessage{
-			Type:    "feat",
-			Subject: "oi",
-			Body:    "Something fun\n\nSecond paragraph\n\nReference to: abcdef1234\nMalformed commit",
+			Type:       "feat",
+			Subject:    "oi",
+			Body:       "Something fun\n\nSecond paragraph\n\nReference to: abcdef1234\nMalformed commit",
+			Separation: Separation{BodyPos: 10, BodyBlankLines: 1},
 		},
 	},
 	{
@@ -249,9 +329,10 @@ var tests = []testCase{
 			message: "feat: oi\n\nSomething fun\n\nSecond paragraph\n\nReference-to: ",
 		},
 		want: CommitMessage{
-			Type:    "feat",
-			Subject: "oi",
-			Body:    "Something fun\n\nSecond paragraph\n\nReference-to: ",
+			Type:       "feat",
+			Subject:    "oi",
+			Body:       "Something fun\n\nSecond paragraph\n\nReference-to: ",
+			Separation: Separation{BodyPos: 10, BodyBlankLines: 1},
 		},
 	},
 	{
@@ -266,6 +347,7 @@ var tests = []testCase{
 			Trailers: map[string][]string{
 				"Ticket": {"ABC-100", "DEF-321"},
 			},
+			Separation: Separation{BodyPos: 20, BodyBlankLines: 1, FooterPos: 41, FooterBlankLines: 1},
 		},
 	},
 	{
@@ -281,6 +363,7 @@ var tests = []testCase{
 				"Ticket":   {"ABC-100"},
 				"Security": {"Addresses 1234 by this and that method\nand according to the discussion in incident SEC-34, blah boo blaha"},
 			},
+			Separation: Separation{BodyPos: 20, BodyBlankLines: 1, FooterPos: 41, FooterBlankLines: 1},
 		},
 	},
 	{
@@ -297,6 +380,7 @@ var tests = []testCase{
 				"Ticket":          {"ABC-100"},
 				"BREAKING CHANGE": {"Yup!"},
 			},
+			Separation: Separation{BodyPos: 22, BodyBlankLines: 1, FooterPos: 43, FooterBlankLines: 1},
 		},
 	},
 	{
@@ -305,9 +389,10 @@ var tests = []testCase{
 			message: "fix: trailer parsing\n\nFixed this and that\n\nBREAKING STUFF: Yup!",
 		},
 		want: CommitMessage{
-			Type:    "fix",
-			Subject: "trailer parsing",
-			Body:    "Fixed this and that\n\nBREAKING STUFF: Yup!",
+			Type:       "fix",
+			Subject:    "trailer parsing",
+			Body:       "Fixed this and that\n\nBREAKING STUFF: Yup!",
+			Separation: Separation{BodyPos: 22, BodyBlankLines: 1},
 		},
 	},
 	{
@@ -316,9 +401,10 @@ var tests = []testCase{
 			message: "fix: trailer parsing\n\nFixed this and that\n\nthis-is-not-upper: nope!",
 		},
 		want: CommitMessage{
-			Type:    "fix",
-			Subject: "trailer parsing",
-			Body:    "Fixed this and that\n\nthis-is-not-upper: nope!",
+			Type:       "fix",
+			Subject:    "trailer parsing",
+			Body:       "Fixed this and that\n\nthis-is-not-upper: nope!",
+			Separation: Separation{BodyPos: 22, BodyBlankLines: 1},
 		},
 	},
 	{
@@ -327,9 +413,196 @@ var tests = []testCase{
 			message: "fix: trailer parsing\n\nFixed this and that\n\nSomething strange: Yup!",
 		},
 		want: CommitMessage{
-			Type:    "fix",
-			Subject: "trailer parsing",
-			Body:    "Fixed this and that\n\nSomething strange: Yup!",
+			Type:       "fix",
+			Subject:    "trailer parsing",
+			Body:       "Fixed this and that\n\nSomething strange: Yup!",
+			Separation: Separation{BodyPos: 22, BodyBlankLines: 1},
+		},
+	},
+	{
+		name: "git_revert",
+		args: args{
+			message: "Revert \"feat(parser): add foo\"\n\nThis reverts commit 8f1c0d6a2b5e4f3a9c7d1e0b2a4c6e8f0a1b3c5d.\n",
+		},
+		want: CommitMessage{
+			Type:    TypeRevert,
+			Subject: "feat(parser): add foo",
+			Body:    "This reverts commit 8f1c0d6a2b5e4f3a9c7d1e0b2a4c6e8f0a1b3c5d.",
+			Revert:  true,
+			Reverted: &CommitMessage{
+				Type:    "feat",
+				Scope:   "parser",
+				Subject: "add foo",
+			},
+			RevertedHashes: []string{"8f1c0d6a2b5e4f3a9c7d1e0b2a4c6e8f0a1b3c5d"},
+			Separation:     Separation{BodyPos: 32, BodyBlankLines: 1},
+		},
+	},
+	{
+		name: "git_revert_of_unconventional",
+		args: args{
+			message: "Revert \"Add foo\"",
+		},
+		want: CommitMessage{
+			Type:    TypeRevert,
+			Subject: "Add foo",
+			Revert:  true,
+		},
+	},
+	{
+		name: "git_reapply",
+		args: args{
+			message: "Reapply \"feat(parser): add foo\"\n\nThis reverts commit 676104e.\n",
+		},
+		want: CommitMessage{
+			Type:    TypeRevert,
+			Subject: "Revert \"feat(parser): add foo\"",
+			Body:    "This reverts commit 676104e.",
+			Revert:  true,
+			Reverted: &CommitMessage{
+				Type:    TypeRevert,
+				Subject: "feat(parser): add foo",
+				Revert:  true,
+				Reverted: &CommitMessage{
+					Type:    "feat",
+					Scope:   "parser",
+					Subject: "add foo",
+				},
+			},
+			RevertedHashes: []string{"676104e"},
+			Separation:     Separation{BodyPos: 33, BodyBlankLines: 1},
+		},
+	},
+	{
+		name: "git_revert_of_revert",
+		args: args{
+			message: "Revert \"Revert \"feat: add foo\"\"\n\nThis reverts commit 676104e.\n",
+		},
+		want: CommitMessage{
+			Type:    TypeRevert,
+			Subject: "Revert \"feat: add foo\"",
+			Body:    "This reverts commit 676104e.",
+			Revert:  true,
+			Reverted: &CommitMessage{
+				Type:    TypeRevert,
+				Subject: "feat: add foo",
+				Revert:  true,
+				Reverted: &CommitMessage{
+					Type:    "feat",
+					Subject: "add foo",
+				},
+			},
+			RevertedHashes: []string{"676104e"},
+			Separation:     Separation{BodyPos: 33, BodyBlankLines: 1},
+		},
+	},
+	{
+		name: "conventional_revert",
+		args: args{
+			message: "revert: let us never again speak of the noodle incident\n\nRefs: 676104e, a215868\n",
+		},
+		want: CommitMessage{
+			Type:           TypeRevert,
+			Subject:        "let us never again speak of the noodle incident",
+			Trailers:       map[string][]string{"Refs": {"676104e, a215868"}},
+			Revert:         true,
+			RevertedHashes: []string{"676104e", "a215868"},
+			Separation:     Separation{FooterPos: 57, FooterBlankLines: 1},
+		},
+	},
+	{
+		name: "git_merge",
+		args: args{
+			message: "Merge branch 'fix-foo' into main\n",
+		},
+		want: CommitMessage{
+			Type:        TypeMerge,
+			Subject:     "branch 'fix-foo' into main",
+			Merge:       true,
+			MergeSource: "fix-foo",
+			MergeTarget: "main",
+		},
+	},
+	{
+		name: "git_merge_remote_tracking",
+		args: args{
+			message: "Merge remote-tracking branch 'origin/fix-foo'",
+		},
+		want: CommitMessage{
+			Type:        TypeMerge,
+			Subject:     "remote-tracking branch 'origin/fix-foo'",
+			Merge:       true,
+			MergeSource: "origin/fix-foo",
+		},
+	},
+	{
+		name: "git_merge_pull",
+		args: args{
+			message: "Merge branch 'main' of https://example.com/foo.git into 'main'",
+		},
+		want: CommitMessage{
+			Type:        TypeMerge,
+			Subject:     "branch 'main' of https://example.com/foo.git into 'main'",
+			Merge:       true,
+			MergeSource: "main",
+			MergeTarget: "main",
+		},
+	},
+	{
+		name: "github_pull_request",
+		args: args{
+			message: "Merge pull request #42 from gopher/fix-foo\n\nfix(foo): avoid panic\n",
+		},
+		want: CommitMessage{
+			Type:        TypeMerge,
+			Subject:     "pull request #42 from gopher/fix-foo",
+			Body:        "fix(foo): avoid panic",
+			Merge:       true,
+			MergeSource: "gopher/fix-foo",
+			Separation:  Separation{BodyPos: 44, BodyBlankLines: 1},
+		},
+	},
+	{
+		name: "fixup",
+		args: args{
+			message: "fixup! feat(parser): add foo\n",
+		},
+		want: CommitMessage{
+			Type:       "feat",
+			Scope:      "parser",
+			Subject:    "add foo",
+			Autosquash: AutosquashFixup,
+		},
+	},
+	{
+		name: "amend_of_squash",
+		args: args{
+			message: "amend! squash! fix: handle empty input\n\nHandle empty input.\n",
+		},
+		want: CommitMessage{
+			Type:       "fix",
+			Subject:    "handle empty input",
+			Body:       "Handle empty input.",
+			Autosquash: AutosquashAmend,
+			Separation: Separation{BodyPos: 40, BodyBlankLines: 1},
+		},
+	},
+	{
+		name: "fixup_of_unconventional",
+		args: args{
+			message: "fixup! WIP\n",
+		},
+		wantErr: true,
+	},
+	{
+		name: "breaking_fixup_type",
+		args: args{
+			message: "fixup!: handle empty input\n",
+		},
+		want: CommitMessage{
+			Type:     "fixup",
+			Subject:  "handle empty input",
+			Breaking: true,
 		},
 	},
 }
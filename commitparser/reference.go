@@ -0,0 +1,100 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package commitparser
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Locations of references that aren't trailers, see [Reference.Location].
+const (
+	LocationSubject = "subject"
+	LocationBody    = "body"
+)
+
+// DefaultReferencePatterns match GitHub-style issue numbers, e.g. #123. Jira-style ticket keys aren't matched by
+// default, since words like UTF-8 and SHA-256 look the same, see [TicketPattern].
+var DefaultReferencePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`#[0-9]+\b`),
+}
+
+// TicketPattern returns a pattern that matches Jira-style ticket keys of the projects, e.g. ABC-123 of project ABC.
+func TicketPattern(projects ...string) *regexp.Regexp {
+	keys := make([]string, 0, len(projects))
+	for _, project := range projects {
+		keys = append(keys, regexp.QuoteMeta(project))
+	}
+
+	return regexp.MustCompile(`\b(?:` + strings.Join(keys, "|") + `)-[0-9]+\b`)
+}
+
+// Reference is a reference to an issue or a ticket in a commit message, e.g. ABC-123 in a Refs trailer.
+type Reference struct {
+	// ID is the text that a pattern matched, or its submatch named id if it has one.
+	ID string
+	// Location is where the reference is, [LocationSubject], [LocationBody] or the key of a trailer.
+	Location string
+}
+
+// References returns the references in the commit message that the patterns match, in the order of the subject, the
+// body and the trailers by key. Only the locations are searched, which are [LocationSubject], [LocationBody] and
+// trailer keys, ignoring case. Every location is searched if none are given. A reference is only returned once per
+// location.
+func (msg CommitMessage) References(patterns []*regexp.Regexp, locations ...string) []Reference {
+	var references []Reference
+
+	search := func(location string, text string) {
+		for _, pattern := range patterns {
+			id := pattern.SubexpIndex("id")
+
+			for _, match := range pattern.FindAllStringSubmatch(text, -1) {
+				ref := Reference{
+					ID:       match[0],
+					Location: location,
+				}
+
+				if id != -1 && match[id] != "" {
+					ref.ID = match[id]
+				}
+
+				if !slices.Contains(references, ref) {
+					references = append(references, ref)
+				}
+			}
+		}
+	}
+
+	searched := func(location string) bool {
+		return len(locations) == 0 || slices.ContainsFunc(locations, func(l string) bool {
+			return strings.EqualFold(l, location)
+		})
+	}
+
+	if searched(LocationSubject) {
+		search(LocationSubject, msg.Subject)
+	}
+
+	if searched(LocationBody) {
+		search(LocationBody, msg.Body)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(msg.Trailers)) {
+		if !searched(key) {
+			continue
+		}
+
+		for _, value := range msg.Trailers[key] {
+			search(key, value)
+		}
+	}
+
+	return references
+}
@@ -0,0 +1,130 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package commitparser
+
+import (
+	"reflect"
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestCommitMessage_References(t *testing.T) {
+	msg, err := Parse("fix(api): handle empty input (#42)\n\nAs reported in #7 and ABC-12, and again in #7.\n\n" +
+		"Refs: ABC-123, ABC-124\nCloses: #42\nReviewed-by: Jane Doe <jane@example.com>\n")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	tickets := append(slices.Clone(DefaultReferencePatterns), TicketPattern("ABC"))
+
+	tests := []struct {
+		name      string
+		patterns  []*regexp.Regexp
+		locations []string
+		want      []Reference
+	}{
+		{
+			name:     "default",
+			patterns: DefaultReferencePatterns,
+			want: []Reference{
+				{ID: "#42", Location: LocationSubject},
+				{ID: "#7", Location: LocationBody},
+				{ID: "#42", Location: "Closes"},
+			},
+		},
+		{
+			name:     "everywhere",
+			patterns: tickets,
+			want: []Reference{
+				{ID: "#42", Location: LocationSubject},
+				{ID: "#7", Location: LocationBody},
+				{ID: "ABC-12", Location: LocationBody},
+				{ID: "#42", Location: "Closes"},
+				{ID: "ABC-123", Location: "Refs"},
+				{ID: "ABC-124", Location: "Refs"},
+			},
+		},
+		{
+			name:      "locations",
+			patterns:  tickets,
+			locations: []string{LocationBody, "refs"},
+			want: []Reference{
+				{ID: "#7", Location: LocationBody},
+				{ID: "ABC-12", Location: LocationBody},
+				{ID: "ABC-123", Location: "Refs"},
+				{ID: "ABC-124", Location: "Refs"},
+			},
+		},
+		{
+			name:     "submatch",
+			patterns: []*regexp.Regexp{regexp.MustCompile(`#(?P<id>[0-9]+)`)},
+			want: []Reference{
+				{ID: "42", Location: LocationSubject},
+				{ID: "7", Location: LocationBody},
+				{ID: "42", Location: "Closes"},
+			},
+		},
+		{
+			name:      "none",
+			patterns:  tickets,
+			locations: []string{"Fixes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := msg.References(tt.patterns, tt.locations...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("References() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitMessage_References_notReferences(t *testing.T) {
+	patterns := append(slices.Clone(DefaultReferencePatterns), TicketPattern("ABC", "DEF"))
+
+	tests := []struct {
+		name    string
+		message string
+	}{
+		{
+			name:    "encoding",
+			message: "fix: decode the input as UTF-8\n",
+		},
+		{
+			name:    "hash",
+			message: "feat: sign releases\n\nThe checksums are SHA-256 and the signatures are ED-25519.\n",
+		},
+		{
+			name:    "date",
+			message: "fix: format dates as ISO-8601\n\nRefs: RFC-3339\n",
+		},
+		{
+			name:    "other_project",
+			message: "fix: handle empty input\n\nRefs: ABCD-123, XABC-12\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			msg, err := Parse(tt.message)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			if got := msg.References(patterns); got != nil {
+				t.Errorf("References() = %v, want none", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package commitparser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// trailerLinePattern matches a line that looks like the start of a trailer.
+var trailerLinePattern = regexp.MustCompile(`^(?:[A-Z][A-Za-z0-9-]*|` + TrailerKeyBreakingChange + `)(?:: | #)\S`)
+
+// separate records how the sections of the parsed message were separated, see [Separation].
+func (p *parser) separate() {
+	headerEnd := strings.IndexByte(p.msg, '\n')
+	if headerEnd == -1 {
+		return
+	}
+
+	separation := &p.commit.Separation
+
+	// The end of the section that precedes the footer.
+	end := headerEnd
+
+	if p.commit.Body != "" {
+		if i := strings.Index(p.msg[headerEnd:], p.commit.Body); i >= 0 {
+			separation.BodyPos = headerEnd + i
+			separation.BodyBlankLines = blankLines(p.msg[headerEnd:separation.BodyPos])
+			end = separation.BodyPos + len(p.commit.Body)
+		}
+	}
+
+	if p.commit.Trailers != nil {
+		// The trailers are the last paragraph.
+		separation.FooterPos = strings.LastIndex(strings.TrimRight(p.msg, "\n"), "\n\n") + 2
+		separation.FooterBlankLines = blankLines(p.msg[end:separation.FooterPos])
+
+		return
+	}
+
+	if separation.BodyPos > 0 {
+		if i := unseparatedFooter(p.commit.Body); i >= 0 {
+			separation.FooterPos = separation.BodyPos + i
+		}
+	}
+}
+
+// blankLines counts the blank lines between two sections. The text starts with the newline that ends the first section.
+func blankLines(between string) int {
+	return max(strings.Count(between, "\n")-1, 0)
+}
+
+// unseparatedFooter returns the byte offset of the lines at the end of the body that look like trailers, but that are
+// part of the last paragraph of the body. It returns -1 if there are no such lines.
+func unseparatedFooter(body string) int {
+	paragraph := strings.LastIndex(body, "\n\n") + 1
+	if paragraph > 0 {
+		paragraph++
+	}
+
+	lines := strings.SplitAfter(body[paragraph:], "\n")
+
+	footer := -1
+	pos := len(body)
+
+	for i := len(lines) - 1; i > 0; i-- {
+		pos -= len(lines[i])
+
+		if !trailerLinePattern.MatchString(lines[i]) {
+			break
+		}
+
+		footer = pos
+	}
+
+	return footer
+}
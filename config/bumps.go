@@ -0,0 +1,61 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alecthomas/kong"
+)
+
+// Bumps maps commit types, or types with a scope such as `chore(deps)`, to the part of the version that they bump:
+// `major`, `minor`, `patch` or `none`.
+//
+// In a configuration file, it's a table of bumps. On the command-line, bumps are given as a comma-separated list of
+// `TYPE=BUMP` pairs.
+type Bumps map[string]string
+
+// Decode implements [kong.MapperValue].
+func (bumps *Bumps) Decode(ctx *kong.DecodeContext) error {
+	token := ctx.Scan.Pop()
+	if token.IsEOL() {
+		return fmt.Errorf("missing value, expecting \"TYPE=BUMP,...\"")
+	}
+
+	if *bumps == nil {
+		*bumps = make(Bumps)
+	}
+
+	switch v := token.Value.(type) {
+	case string:
+		for _, pair := range strings.Split(v, ",") {
+			key, bump, found := strings.Cut(pair, "=")
+			if !found {
+				return fmt.Errorf("expected TYPE=BUMP but got %q", pair)
+			}
+
+			(*bumps)[strings.TrimSpace(key)] = strings.TrimSpace(bump)
+		}
+
+	case map[string]any:
+		for key, value := range v {
+			bump, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("%q: expected a bump to be a string but got %T", key, value)
+			}
+
+			(*bumps)[key] = bump
+		}
+
+	default:
+		return fmt.Errorf("expected a table of bumps but got %T", token.Value)
+	}
+
+	return nil
+}
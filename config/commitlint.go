@@ -0,0 +1,392 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter/conventionalcommits"
+)
+
+// CommitlintBaseName is the name of the configuration file of commitlint without its extension.
+const CommitlintBaseName = ".commitlintrc"
+
+// commitlintConventional is the shareable configuration of commitlint that the default policy corresponds to.
+const commitlintConventional = "@commitlint/config-conventional"
+
+// commitlintConventionalRules are the rules of [commitlintConventional] at its levels. The rules header-trim and
+// type-case are left out, since git strips the header and type-enum only allows the conventional types, which are in
+// lower case.
+var commitlintConventionalRules = map[string]any{
+	"body-leading-blank":     []any{1, "always"},
+	"body-max-line-length":   []any{2, "always", 100},
+	"footer-leading-blank":   []any{1, "always"},
+	"footer-max-line-length": []any{2, "always", 100},
+	"header-max-length":      []any{2, "always", 100},
+	"subject-case":           []any{2, "never", []any{"sentence-case", "start-case", "pascal-case", "upper-case"}},
+	"subject-empty":          []any{2, "never"},
+	"subject-full-stop":      []any{2, "never", "."},
+	"type-empty":             []any{2, "never"},
+	"type-enum": []any{2, "always", []any{
+		"build", "chore", "ci", "docs", "feat", "fix", "perf", "refactor", "revert", "style", "test",
+	}},
+}
+
+var (
+	ErrUnmappedRule       = errors.New("commitlint rule can't be mapped onto a commit-tool rule")
+	ErrPartialMapping     = errors.New("commitlint rule is only partially mapped onto a commit-tool rule")
+	ErrUnsupportedSetting = errors.New("commitlint setting is not supported")
+	ErrBadCommitlintRule  = errors.New("bad commitlint rule")
+)
+
+// commitlintRule is a rule in a commitlint configuration, which is an array of a level, whether the rule is applicable
+// (always) or the inverse (never) and a value.
+type commitlintRule struct {
+	Level      int
+	Applicable string
+	Value      any
+}
+
+// commitlintValues are the values of flags that a commitlint configuration is mapped onto.
+type commitlintValues struct {
+	types  []any
+	scopes []any
+	rules  map[string]map[string]any
+}
+
+// commitlintMappers map commitlint rules onto rules of the conventionalcommits package. A mapper is only called if the
+// rule is enabled, a disabled commitlint rule disables the rule that it maps onto. A mapper that returns an error that
+// wraps ErrPartialMapping has mapped the rule, but the rule it maps onto checks less than the commitlint rule.
+var commitlintMappers = map[string]struct {
+	// id is the rule that the commitlint rule maps onto, if any.
+	id string
+	fn func(rule commitlintRule, values *commitlintValues) error
+}{
+	"type-enum": {
+		id: conventionalcommits.RuleTypeEnum,
+		fn: func(rule commitlintRule, values *commitlintValues) error {
+			types, ok := rule.Value.([]any)
+			if rule.Applicable != "always" || !ok {
+				return ErrUnmappedRule
+			}
+
+			values.types = types
+
+			return nil
+		},
+	},
+	"type-empty": {
+		fn: func(rule commitlintRule, _ *commitlintValues) error {
+			// The header-format rule always requires a type.
+			if rule.Applicable != "never" {
+				return ErrUnmappedRule
+			}
+
+			return nil
+		},
+	},
+	"scope-enum": {
+		id: conventionalcommits.RuleScopeEnum,
+		fn: func(rule commitlintRule, values *commitlintValues) error {
+			scopes, ok := rule.Value.([]any)
+			if rule.Applicable != "always" || !ok {
+				return ErrUnmappedRule
+			}
+
+			values.scopes = scopes
+
+			return nil
+		},
+	},
+	"scope-empty": {
+		// The scope-enum rule can require a scope, but disabling scope-empty mustn't disable scope-enum.
+		fn: func(rule commitlintRule, values *commitlintValues) error {
+			if rule.Applicable != "never" {
+				return ErrUnmappedRule
+			}
+
+			values.rule(conventionalcommits.RuleScopeEnum)["required"] = true
+			values.enable(conventionalcommits.RuleScopeEnum, rule.Level)
+
+			return nil
+		},
+	},
+	"subject-empty": {
+		id: conventionalcommits.RuleSubjectEmpty,
+		fn: applicable("never"),
+	},
+	"subject-case": {
+		id: conventionalcommits.RuleSubjectCase,
+		fn: func(rule commitlintRule, _ *commitlintValues) error {
+			cases, ok := rule.Value.([]any)
+			if !ok {
+				cases = []any{rule.Value}
+			}
+
+			// The subject-case rule rejects subjects that start with upper case, which is what these cases have in
+			// common.
+			for _, c := range cases {
+				switch {
+				case rule.Applicable == "never" && slices.Contains([]any{"upper-case", "sentence-case", "start-case", "pascal-case"}, c):
+					return nil
+				case rule.Applicable == "always" && c == "lower-case":
+					return fmt.Errorf("only the first character of the subject is checked: %w", ErrPartialMapping)
+				}
+			}
+
+			return ErrUnmappedRule
+		},
+	},
+	"subject-full-stop": {
+		id: conventionalcommits.RuleSubjectFullStop,
+		fn: applicable("never"),
+	},
+	"body-leading-blank": {
+		id: conventionalcommits.RuleBodyLeadingBlank,
+		fn: applicable("always"),
+	},
+	"footer-leading-blank": {
+		id: conventionalcommits.RuleFooterLeadingBlank,
+		fn: applicable("always"),
+	},
+	"references-empty": {
+		id: conventionalcommits.RuleIssueReference,
+		fn: func(rule commitlintRule, values *commitlintValues) error {
+			if rule.Applicable != "never" {
+				return ErrUnmappedRule
+			}
+
+			// commitlint requires every commit to reference an issue.
+			values.rule(conventionalcommits.RuleIssueReference)["types"] = []any{}
+
+			return nil
+		},
+	},
+	"signed-off-by": {
+		id: conventionalcommits.RuleSignedOffBy,
+		fn: func(rule commitlintRule, _ *commitlintValues) error {
+			// The value is the trailer that commitlint looks for, only the default trailer can be mapped.
+			if value, _ := rule.Value.(string); rule.Applicable != "always" || (value != "" && value != "Signed-off-by:") {
+				return ErrUnmappedRule
+			}
+
+			return nil
+		},
+	},
+	"header-max-length": {
+		id: conventionalcommits.RuleHeaderMaxLength,
+		fn: length(conventionalcommits.RuleHeaderMaxLength, "max"),
+	},
+	"header-min-length": {
+		id: conventionalcommits.RuleHeaderMinLength,
+		fn: length(conventionalcommits.RuleHeaderMinLength, "min"),
+	},
+	"body-max-line-length": {
+		id: conventionalcommits.RuleBodyMaxLineLength,
+		fn: length(conventionalcommits.RuleBodyMaxLineLength, "max"),
+	},
+	"footer-max-line-length": {
+		id: conventionalcommits.RuleFooterMaxLineLength,
+		fn: length(conventionalcommits.RuleFooterMaxLineLength, "max"),
+	},
+}
+
+// applicable returns a mapper of rules that can only be mapped if they are applied as given, e.g. "never".
+func applicable(applicable string) func(rule commitlintRule, values *commitlintValues) error {
+	return func(rule commitlintRule, _ *commitlintValues) error {
+		if rule.Applicable != applicable {
+			return ErrUnmappedRule
+		}
+
+		return nil
+	}
+}
+
+// length returns a mapper of rules that always apply a length, which is set as the option of the rule.
+func length(id string, option string) func(rule commitlintRule, values *commitlintValues) error {
+	return func(rule commitlintRule, values *commitlintValues) error {
+		n, ok := rule.Value.(int)
+		if rule.Applicable != "always" || !ok {
+			return ErrUnmappedRule
+		}
+
+		values.rule(id)[option] = n
+
+		return nil
+	}
+}
+
+// Commitlint decodes a commitlint configuration file, in JSON or YAML, into the values of flags. The rules that can be
+// mapped onto commit-tool rules are imported, anything else is returned as warnings.
+func Commitlint(r io.Reader) (map[string]any, []error, error) {
+	var config map[string]any
+
+	if err := yaml.NewDecoder(r).Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, nil, err
+	}
+
+	var warnings []error
+
+	// The rules of the configuration override the rules of the configurations it extends.
+	rules := make(map[string]any)
+
+	for _, key := range slices.Sorted(maps.Keys(config)) {
+		switch key {
+		case "rules":
+		case "extends":
+			extended, extendsWarnings := commitlintExtends(config[key])
+
+			maps.Copy(rules, extended)
+			warnings = append(warnings, extendsWarnings...)
+		case "helpUrl", "prompt":
+			// Only used by commitlint to show help and prompt for messages.
+		default:
+			warnings = append(warnings, fmt.Errorf("%q: %w", key, ErrUnsupportedSetting))
+		}
+	}
+
+	configured, ok := config["rules"].(map[string]any)
+	if !ok && config["rules"] != nil {
+		return nil, nil, fmt.Errorf("expected rules to be a table but got %T", config["rules"])
+	}
+
+	maps.Copy(rules, configured)
+
+	values := commitlintValues{
+		rules: make(map[string]map[string]any),
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(rules)) {
+		rule, err := decodeCommitlintRule(rules[name])
+		if err != nil {
+			return nil, nil, fmt.Errorf("rule %q: %w", name, err)
+		}
+
+		mapper, found := commitlintMappers[name]
+
+		switch {
+		case rule.Level == 0 && found && mapper.id != "":
+			values.rule(mapper.id)["enabled"] = false
+		case rule.Level == 0:
+			// Rules that are disabled don't need to be mapped.
+		case !found:
+			warnings = append(warnings, fmt.Errorf("%q: %w", name, ErrUnmappedRule))
+		default:
+			if err = mapper.fn(rule, &values); err != nil {
+				warnings = append(warnings, fmt.Errorf("%q (%s %v): %w", name, rule.Applicable, rule.Value, err))
+
+				if !errors.Is(err, ErrPartialMapping) {
+					continue
+				}
+			}
+
+			if mapper.id != "" {
+				values.enable(mapper.id, rule.Level)
+			}
+		}
+	}
+
+	result := make(map[string]any)
+
+	if values.types != nil {
+		result["types"] = values.types
+	}
+
+	if values.scopes != nil {
+		result["scopes"] = values.scopes
+	}
+
+	if len(values.rules) > 0 {
+		rules := make(map[string]any, len(values.rules))
+		for id, rule := range values.rules {
+			rules[id] = rule
+		}
+
+		result["rules"] = rules
+	}
+
+	return result, warnings, nil
+}
+
+// commitlintExtends returns the rules of the shareable configurations that are extended, and warnings for those that
+// can't be. Only the conventional configuration is known.
+func commitlintExtends(value any) (map[string]any, []error) {
+	extends, ok := value.([]any)
+	if !ok {
+		extends = []any{value}
+	}
+
+	rules := make(map[string]any)
+
+	var warnings []error
+
+	for _, extend := range extends {
+		if extend != commitlintConventional {
+			warnings = append(warnings, fmt.Errorf("extends %q: %w", extend, ErrUnsupportedSetting))
+			continue
+		}
+
+		maps.Copy(rules, commitlintConventionalRules)
+	}
+
+	return rules, warnings
+}
+
+func decodeCommitlintRule(value any) (commitlintRule, error) {
+	array, ok := value.([]any)
+	if !ok || len(array) == 0 {
+		return commitlintRule{}, fmt.Errorf("expected an array of a level, always or never and a value: %w", ErrBadCommitlintRule)
+	}
+
+	rule := commitlintRule{
+		Applicable: "always",
+	}
+
+	if rule.Level, ok = array[0].(int); !ok || rule.Level < 0 || rule.Level > 2 {
+		return commitlintRule{}, fmt.Errorf("expected the level to be 0, 1 or 2 but got %v: %w", array[0], ErrBadCommitlintRule)
+	}
+
+	if len(array) > 1 {
+		if rule.Applicable, ok = array[1].(string); !ok || (rule.Applicable != "always" && rule.Applicable != "never") {
+			return commitlintRule{}, fmt.Errorf("expected always or never but got %v: %w", array[1], ErrBadCommitlintRule)
+		}
+	}
+
+	if len(array) > 2 {
+		rule.Value = array[2]
+	}
+
+	return rule, nil
+}
+
+// rule returns the table of options of the rule.
+func (values *commitlintValues) rule(id string) map[string]any {
+	if values.rules[id] == nil {
+		values.rules[id] = make(map[string]any)
+	}
+
+	return values.rules[id]
+}
+
+// enable enables the rule with the severity of the commitlint level.
+func (values *commitlintValues) enable(id string, level int) {
+	rule := values.rule(id)
+
+	rule["enabled"] = true
+	rule["severity"] = "error"
+
+	if level == 1 {
+		rule["severity"] = "warning"
+	}
+}
@@ -0,0 +1,175 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+// Package config implements loading of the repository configuration file.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/alecthomas/kong"
+	"gopkg.in/yaml.v3"
+)
+
+// BaseName is the name of the configuration file without its extension.
+const BaseName = ".commit-tool"
+
+var (
+	ErrNotFound = errors.New("no configuration file found")
+	ErrTopLevel = errors.New("can only be set at the top level")
+)
+
+// File is a loaded configuration file.
+type File struct {
+	Path     string
+	Resolver kong.Resolver
+	// Warnings are problems that didn't prevent the file from being loaded, e.g. settings that can't be imported.
+	Warnings []error
+}
+
+// valuesLoader decodes a configuration file into the values of flags. The warnings are problems that didn't prevent the
+// file from being decoded.
+type valuesLoader func(r io.Reader) (map[string]any, []error, error)
+
+// loaders are tried in order in each directory, so the configuration file of commit-tool is preferred over the
+// configuration file of commitlint.
+var loaders = []struct {
+	name   string
+	loader valuesLoader
+}{
+	{name: BaseName + ".yaml", loader: yamlValues},
+	{name: BaseName + ".yml", loader: yamlValues},
+	{name: BaseName + ".toml", loader: tomlValues},
+	{name: CommitlintBaseName, loader: Commitlint},
+	{name: CommitlintBaseName + ".json", loader: Commitlint},
+	{name: CommitlintBaseName + ".yaml", loader: Commitlint},
+	{name: CommitlintBaseName + ".yml", loader: Commitlint},
+}
+
+// Find walks up from dir until it finds a configuration file and returns the path to it.
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("cannot resolve directory %q: %w", dir, err)
+	}
+
+	for {
+		for _, l := range loaders {
+			path := filepath.Join(dir, l.name)
+
+			info, err := os.Stat(path)
+			if err == nil && info.Mode().IsRegular() {
+				return path, nil
+			}
+
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return "", fmt.Errorf("cannot stat %q: %w", path, err)
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ErrNotFound
+		}
+
+		dir = parent
+	}
+}
+
+// Load finds and loads the configuration file closest to dir.
+func Load(dir string) (File, error) {
+	path, err := Find(dir)
+	if err != nil {
+		return File{}, err
+	}
+
+	var loader valuesLoader
+
+	for _, l := range loaders {
+		if l.name == filepath.Base(path) {
+			loader = l.loader
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return File{}, fmt.Errorf("cannot open configuration file: %w", err)
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	values, warnings, err := loader(f)
+	if err != nil {
+		return File{}, fmt.Errorf("cannot load configuration file %q: %w", path, err)
+	}
+
+	return File{
+		Path:     path,
+		Resolver: resolver(values),
+		Warnings: warnings,
+	}, nil
+}
+
+func yamlValues(r io.Reader) (map[string]any, []error, error) {
+	values := make(map[string]any)
+
+	if err := yaml.NewDecoder(r).Decode(&values); err != nil && !errors.Is(err, io.EOF) {
+		return nil, nil, err
+	}
+
+	return values, nil, nil
+}
+
+func tomlValues(r io.Reader) (map[string]any, []error, error) {
+	values := make(map[string]any)
+
+	if _, err := toml.NewDecoder(r).Decode(&values); err != nil {
+		return nil, nil, err
+	}
+
+	return values, nil, nil
+}
+
+// resolver resolves flag values from the configuration. A value in a table named after the command takes precedence
+// over a top-level value, which allows the same key to be set differently for each command. Flags that are tagged
+// toplevel, which every command must agree on, can only be set at the top level.
+func resolver(values map[string]any) kong.Resolver {
+	return kong.ResolverFunc(func(_ *kong.Context, parent *kong.Path, flag *kong.Flag) (any, error) {
+		if parent != nil && parent.Command != nil {
+			if section, ok := lookup(values, parent.Command.Name).(map[string]any); ok {
+				if v := lookup(section, flag.Name); v != nil && flag.Tag.Has("toplevel") {
+					return nil, fmt.Errorf("%s.%s: %w", parent.Command.Name, flag.Name, ErrTopLevel)
+				} else if v != nil {
+					return v, nil
+				}
+			}
+		}
+
+		return lookup(values, flag.Name), nil
+	})
+}
+
+// lookup finds the value of a key, accepting both kebab-case and snake_case keys.
+func lookup(values map[string]any, name string) any {
+	if v, ok := values[name]; ok {
+		return v
+	}
+
+	if v, ok := values[strings.ReplaceAll(name, "-", "_")]; ok {
+		return v
+	}
+
+	return nil
+}
@@ -0,0 +1,424 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	"github.com/google/go-cmp/cmp"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter/conventionalcommits"
+	"codeberg.org/somebadcode/commit-tool/config"
+)
+
+type testCLI struct {
+	Lint struct {
+		Types      []string     `kong:"sep=','"`
+		Rules      config.Rules `kong:""`
+		ScopePaths config.Paths `kong:"name='scope-paths'"`
+		Bumps      config.Bumps `kong:"name='bumps',toplevel"`
+		VSuffix    bool         `kong:"name='v-suffix'"`
+	} `kong:"cmd"`
+}
+
+func TestLoad(t *testing.T) {
+	type want struct {
+		Types      []string
+		Rules      config.Rules
+		ScopePaths config.Paths
+		Bumps      config.Bumps
+		VSuffix    bool
+	}
+
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		args     []string
+		want     want
+	}{
+		{
+			name:     "yaml",
+			filename: ".commit-tool.yaml",
+			content:  "types: [feat, fix]\nrules:\n  subject-case: false\n  scope-enum:\n    required: true\n",
+			want: want{
+				Types: []string{"feat", "fix"},
+				Rules: config.Rules{
+					"subject-case": {Enabled: false},
+					"scope-enum":   {Enabled: true, Options: map[string]any{"required": true}},
+				},
+			},
+		},
+		{
+			name:     "toml_section",
+			filename: ".commit-tool.toml",
+			content:  "types = [\"feat\"]\nv_suffix = false\n\n[lint]\nv-suffix = true\n\n[lint.rules.scope-enum]\nenabled = false\n",
+			want: want{
+				Types:   []string{"feat"},
+				VSuffix: true,
+				Rules: config.Rules{
+					"scope-enum": {Enabled: false, Options: map[string]any{}},
+				},
+			},
+		},
+		{
+			name:     "command_line_wins",
+			filename: ".commit-tool.yml",
+			content:  "types: [feat, fix]\nrules:\n  subject-case: false\n",
+			args:     []string{"--types=chore", "--rules=subject-case=true,type-enum=false"},
+			want: want{
+				Types: []string{"chore"},
+				Rules: config.Rules{
+					"subject-case": {Enabled: true},
+					"type-enum":    {Enabled: false},
+				},
+			},
+		},
+		{
+			name:     "severities",
+			filename: ".commit-tool.yaml",
+			content:  "rules:\n  subject-case: warning\n  subject-full-stop: off\n  scope-enum:\n    severity: info\n",
+			want: want{
+				Rules: config.Rules{
+					"subject-case":      {Enabled: true, Severity: "warning"},
+					"subject-full-stop": {Enabled: false},
+					"scope-enum":        {Enabled: true, Severity: "info", Options: map[string]any{}},
+				},
+			},
+		},
+		{
+			name:     "command_line_severities",
+			filename: ".commit-tool.yaml",
+			content:  "types: [feat, fix]\n",
+			args:     []string{"--rules=type-enum=warning,subject-case=off"},
+			want: want{
+				Types: []string{"feat", "fix"},
+				Rules: config.Rules{
+					"type-enum":    {Enabled: true, Severity: "warning"},
+					"subject-case": {Enabled: false},
+				},
+			},
+		},
+		{
+			name:     "scope_paths",
+			filename: ".commit-tool.yaml",
+			content:  "scope-paths:\n  api: services/api/**\n  web: [web/**, '!web/README.md']\n",
+			want: want{
+				ScopePaths: config.Paths{
+					"api": {"services/api/**"},
+					"web": {"web/**", "!web/README.md"},
+				},
+			},
+		},
+		{
+			name:     "command_line_scope_paths",
+			filename: ".commit-tool.yaml",
+			content:  "scope-paths:\n  api: services/api/**\n",
+			args:     []string{"--scope-paths=cli=cmd/**,cli=main.go"},
+			want: want{
+				ScopePaths: config.Paths{
+					"cli": {"cmd/**", "main.go"},
+				},
+			},
+		},
+		{
+			name:     "bumps",
+			filename: ".commit-tool.yaml",
+			content:  "bumps:\n  perf: patch\n  chore(deps): patch\n  docs: none\n",
+			args:     []string{"--bumps=api=minor"},
+			want: want{
+				Bumps: config.Bumps{"api": "minor"},
+			},
+		},
+		{
+			name:     "bumps_file",
+			filename: ".commit-tool.toml",
+			content:  "[bumps]\nperf = \"patch\"\n\"chore(deps)\" = \"patch\"\n",
+			want: want{
+				Bumps: config.Bumps{"perf": "patch", "chore(deps)": "patch"},
+			},
+		},
+		{
+			name:     "commitlint_json",
+			filename: ".commitlintrc.json",
+			content: `{
+  "extends": ["@commitlint/config-conventional"],
+  "rules": {
+    "type-enum": [2, "always", ["feat", "fix"]],
+    "subject-case": [1, "never", ["sentence-case", "start-case", "pascal-case", "upper-case"]],
+    "subject-full-stop": [0, "never", "."],
+    "header-max-length": [2, "always", 72]
+  }
+}`,
+			want: want{
+				Types: []string{"feat", "fix"},
+				Rules: config.Rules{
+					"body-leading-blank":     {Enabled: true, Severity: "warning", Options: map[string]any{}},
+					"body-max-line-length":   {Enabled: true, Severity: "error", Options: map[string]any{"max": 100}},
+					"footer-leading-blank":   {Enabled: true, Severity: "warning", Options: map[string]any{}},
+					"footer-max-line-length": {Enabled: true, Severity: "error", Options: map[string]any{"max": 100}},
+					"header-max-length":      {Enabled: true, Severity: "error", Options: map[string]any{"max": 72}},
+					"type-enum":              {Enabled: true, Severity: "error", Options: map[string]any{}},
+					"subject-case":           {Enabled: true, Severity: "warning", Options: map[string]any{}},
+					"subject-empty":          {Enabled: true, Severity: "error", Options: map[string]any{}},
+					"subject-full-stop":      {Enabled: false, Options: map[string]any{}},
+				},
+			},
+		},
+		{
+			name:     "commitlint_extends",
+			filename: ".commitlintrc.yml",
+			content:  "extends: \"@commitlint/config-conventional\"\n",
+			want: want{
+				Types: conventionalcommits.DefaultTypes,
+				Rules: config.Rules{
+					"body-leading-blank":     {Enabled: true, Severity: "warning", Options: map[string]any{}},
+					"body-max-line-length":   {Enabled: true, Severity: "error", Options: map[string]any{"max": 100}},
+					"footer-leading-blank":   {Enabled: true, Severity: "warning", Options: map[string]any{}},
+					"footer-max-line-length": {Enabled: true, Severity: "error", Options: map[string]any{"max": 100}},
+					"header-max-length":      {Enabled: true, Severity: "error", Options: map[string]any{"max": 100}},
+					"subject-case":           {Enabled: true, Severity: "error", Options: map[string]any{}},
+					"subject-empty":          {Enabled: true, Severity: "error", Options: map[string]any{}},
+					"subject-full-stop":      {Enabled: true, Severity: "error", Options: map[string]any{}},
+					"type-enum":              {Enabled: true, Severity: "error", Options: map[string]any{}},
+				},
+			},
+		},
+		{
+			name:     "commitlint_partial",
+			filename: ".commitlintrc.yml",
+			content:  "rules:\n  subject-case: [1, always, lower-case]\n",
+			want: want{
+				Rules: config.Rules{
+					"subject-case": {Enabled: true, Severity: "warning", Options: map[string]any{}},
+				},
+			},
+		},
+		{
+			name:     "commitlint_yaml",
+			filename: ".commitlintrc.yaml",
+			content:  "rules:\n  scope-empty: [2, never]\n  scope-enum: [1, always, [api, cli]]\n",
+			want: want{
+				Rules: config.Rules{
+					"scope-enum": {Enabled: true, Severity: "warning", Options: map[string]any{"required": true}},
+				},
+			},
+		},
+	}
+
+	t.Parallel()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := t.TempDir()
+			dir := filepath.Join(root, "a", "b")
+
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := os.WriteFile(filepath.Join(root, tt.filename), []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			file, err := config.Load(dir)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+
+			if want := filepath.Join(root, tt.filename); file.Path != want {
+				t.Errorf("Load() path = %q, want %q", file.Path, want)
+			}
+
+			var cli testCLI
+
+			parser, err := kong.New(&cli, kong.Resolvers(file.Resolver))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err = parser.Parse(append([]string{"lint"}, tt.args...)); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			got := want{
+				Types:      cli.Lint.Types,
+				Rules:      cli.Lint.Rules,
+				ScopePaths: cli.Lint.ScopePaths,
+				Bumps:      cli.Lint.Bumps,
+				VSuffix:    cli.Lint.VSuffix,
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Load() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoad_topLevel(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+
+	content := "bumps:\n  perf: patch\nlint:\n  bumps:\n    api: minor\n"
+	if err := os.WriteFile(filepath.Join(root, ".commit-tool.yaml"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := config.Load(root)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	var cli testCLI
+
+	parser, err := kong.New(&cli, kong.Resolvers(file.Resolver))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = parser.Parse([]string{"lint"}); !errors.Is(err, config.ErrTopLevel) {
+		t.Errorf("Parse() error = %v, want %v", err, config.ErrTopLevel)
+	}
+}
+
+func TestFind_NotFound(t *testing.T) {
+	t.Parallel()
+
+	// The temporary directory is assumed to not be below a directory with a configuration file.
+	if _, err := config.Find(t.TempDir()); !errors.Is(err, config.ErrNotFound) {
+		t.Errorf("Find() error = %v, want %v", err, config.ErrNotFound)
+	}
+}
+
+func TestFind_preference(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	dir := filepath.Join(root, "sub")
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	// The configuration file of commit-tool is preferred in the same directory, but the closest file wins.
+	for _, path := range []string{
+		filepath.Join(root, ".commit-tool.yaml"),
+		filepath.Join(root, ".commitlintrc.json"),
+		filepath.Join(dir, ".commitlintrc"),
+	} {
+		if err := os.WriteFile(path, []byte("{}"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{dir: root, want: filepath.Join(root, ".commit-tool.yaml")},
+		{dir: dir, want: filepath.Join(dir, ".commitlintrc")},
+	}
+
+	for _, tt := range tests {
+		got, err := config.Find(tt.dir)
+		if err != nil {
+			t.Fatalf("Find() error = %v", err)
+		}
+
+		if got != tt.want {
+			t.Errorf("Find() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestCommitlint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		content      string
+		wantWarnings []string
+		wantErr      error
+	}{
+		{
+			name:    "mapped",
+			content: `{"extends": "@commitlint/config-conventional", "rules": {"type-empty": [2, "never"], "subject-empty": [2, "never"], "body-leading-blank": [1, "always"]}}`,
+		},
+		{
+			name:    "trailer_rules",
+			content: `{"rules": {"signed-off-by": [2, "always", "Signed-off-by:"], "trailer-exists": [2, "always", "Signed-off-by:"], "references-empty": [1, "never"]}}`,
+			wantWarnings: []string{
+				`"trailer-exists": commitlint rule can't be mapped onto a commit-tool rule`,
+			},
+		},
+		{
+			name: "unmapped",
+			content: `{
+  "extends": ["@commitlint/config-angular"],
+  "parserPreset": "conventional-changelog-atom",
+  "rules": {
+    "body-case": [2, "always", "lower-case"],
+    "header-case": [0, "always", "lower-case"],
+    "subject-full-stop": [2, "always", "."]
+  }
+}`,
+			wantWarnings: []string{
+				`extends "@commitlint/config-angular": commitlint setting is not supported`,
+				`"parserPreset": commitlint setting is not supported`,
+				`"body-case": commitlint rule can't be mapped onto a commit-tool rule`,
+				`"subject-full-stop" (always .): commitlint rule can't be mapped onto a commit-tool rule`,
+			},
+		},
+		{
+			name:    "partial",
+			content: "rules:\n  subject-case: [2, always, lower-case]\n",
+			wantWarnings: []string{
+				`"subject-case" (always lower-case): only the first character of the subject is checked: commitlint rule is only partially mapped onto a commit-tool rule`,
+			},
+		},
+		{
+			name:    "bad_level",
+			content: `{"rules": {"type-enum": ["error", "always", ["feat"]]}}`,
+			wantErr: config.ErrBadCommitlintRule,
+		},
+		{
+			name:    "bad_applicable",
+			content: "rules:\n  type-enum: [2, sometimes]\n",
+			wantErr: config.ErrBadCommitlintRule,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, warnings, err := config.Commitlint(strings.NewReader(tt.content))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Commitlint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var got []string
+			for _, warning := range warnings {
+				got = append(got, warning.Error())
+			}
+
+			if diff := cmp.Diff(tt.wantWarnings, got); diff != "" {
+				t.Errorf("Commitlint() warnings mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
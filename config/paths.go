@@ -0,0 +1,85 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alecthomas/kong"
+)
+
+// Paths maps names, such as scopes or types, to the paths of the repository that they cover, as gitignore-style
+// patterns such as `services/api/**`.
+//
+// In a configuration file, each name has a pattern or a list of patterns. On the command-line, names are given as a
+// comma-separated list of `NAME=PATTERN` pairs, a name may be given more than once.
+type Paths map[string][]string
+
+// Decode implements [kong.MapperValue].
+func (paths *Paths) Decode(ctx *kong.DecodeContext) error {
+	token := ctx.Scan.Pop()
+	if token.IsEOL() {
+		return fmt.Errorf("missing value, expecting \"NAME=PATTERN,...\"")
+	}
+
+	if *paths == nil {
+		*paths = make(Paths)
+	}
+
+	switch v := token.Value.(type) {
+	case string:
+		for _, pair := range strings.Split(v, ",") {
+			name, pattern, found := strings.Cut(pair, "=")
+			if !found {
+				return fmt.Errorf("expected NAME=PATTERN but got %q", pair)
+			}
+
+			name = strings.TrimSpace(name)
+			(*paths)[name] = append((*paths)[name], strings.TrimSpace(pattern))
+		}
+
+	case map[string]any:
+		for name, value := range v {
+			patterns, err := decodePatterns(value)
+			if err != nil {
+				return fmt.Errorf("%q: %w", name, err)
+			}
+
+			(*paths)[name] = patterns
+		}
+
+	default:
+		return fmt.Errorf("expected a table of paths but got %T", token.Value)
+	}
+
+	return nil
+}
+
+func decodePatterns(value any) ([]string, error) {
+	switch v := value.(type) {
+	case string:
+		return []string{v}, nil
+
+	case []any:
+		patterns := make([]string, 0, len(v))
+
+		for _, pattern := range v {
+			s, ok := pattern.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a pattern to be a string but got %T", pattern)
+			}
+
+			patterns = append(patterns, s)
+		}
+
+		return patterns, nil
+	}
+
+	return nil, fmt.Errorf("expected a pattern or a list of patterns but got %T", value)
+}
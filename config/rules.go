@@ -0,0 +1,174 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/kong"
+)
+
+// Rule is the configuration of a single rule.
+type Rule struct {
+	Enabled bool
+	// Severity is the name of the rule's severity, it's empty unless configured.
+	Severity string
+	Options  map[string]any
+}
+
+// Off is the severity value that disables a rule.
+const Off = "off"
+
+// Rules maps rule IDs to their configuration. Rules that aren't present keep their default configuration.
+//
+// In a configuration file, each rule is either a boolean that enables or disables it, a severity that enables it with
+// that severity (or "off" to disable it), or a table of options. A table enables the rule unless it has the option
+// `enabled` set to false, the option `severity` sets the severity. On the command-line, rules are given as a
+// comma-separated list of `ID=BOOL` or `ID=SEVERITY` pairs.
+type Rules map[string]Rule
+
+// Decode implements [kong.MapperValue].
+func (rules *Rules) Decode(ctx *kong.DecodeContext) error {
+	token := ctx.Scan.Pop()
+	if token.IsEOL() {
+		return fmt.Errorf("missing value, expecting \"ID=BOOL|SEVERITY,...\"")
+	}
+
+	if *rules == nil {
+		*rules = make(Rules)
+	}
+
+	switch v := token.Value.(type) {
+	case string:
+		for _, pair := range strings.Split(v, ",") {
+			id, value, found := strings.Cut(pair, "=")
+			if !found {
+				value = "true"
+			}
+
+			if enabled, err := strconv.ParseBool(value); err == nil {
+				(*rules)[strings.TrimSpace(id)] = Rule{Enabled: enabled}
+
+				continue
+			}
+
+			(*rules)[strings.TrimSpace(id)] = severityRule(strings.TrimSpace(value))
+		}
+
+	case map[string]any:
+		for id, value := range v {
+			rule, err := decodeRule(value)
+			if err != nil {
+				return fmt.Errorf("rule %q: %w", id, err)
+			}
+
+			(*rules)[id] = rule
+		}
+
+	default:
+		return fmt.Errorf("expected a table of rules but got %T", token.Value)
+	}
+
+	return nil
+}
+
+func decodeRule(value any) (Rule, error) {
+	switch v := value.(type) {
+	case bool:
+		return Rule{Enabled: v}, nil
+
+	case string:
+		return severityRule(v), nil
+
+	case map[string]any:
+		rule := Rule{
+			Enabled: true,
+			Options: make(map[string]any, len(v)),
+		}
+
+		for k, option := range v {
+			switch k {
+			case "enabled":
+				enabled, ok := option.(bool)
+				if !ok {
+					return Rule{}, fmt.Errorf("expected enabled to be a boolean but got %T", option)
+				}
+
+				rule.Enabled = enabled
+
+			case "severity":
+				severity, ok := option.(string)
+				if !ok {
+					return Rule{}, fmt.Errorf("expected severity to be a string but got %T", option)
+				}
+
+				rule.Severity = severity
+
+			default:
+				rule.Options[k] = option
+			}
+		}
+
+		return rule, nil
+	}
+
+	return Rule{}, fmt.Errorf("expected a boolean, a severity or a table of options but got %T", value)
+}
+
+func severityRule(severity string) Rule {
+	if severity == Off {
+		return Rule{Enabled: false}
+	}
+
+	return Rule{
+		Enabled:  true,
+		Severity: severity,
+	}
+}
+
+// Enabled reports if the rule with the given ID is enabled, falling back to def if it isn't configured.
+func (rules Rules) Enabled(id string, def bool) bool {
+	rule, ok := rules[id]
+	if !ok {
+		return def
+	}
+
+	return rule.Enabled
+}
+
+// Severity returns the configured severity of the rule with the given ID, or an empty string if it isn't configured.
+func (rules Rules) Severity(id string) string {
+	return rules[id].Severity
+}
+
+// DecodeOptions decodes the options of the rule with the given ID into v, which must be a pointer. Options that are not
+// set keep the value that v already has.
+func (rules Rules) DecodeOptions(id string, v any) error {
+	rule, ok := rules[id]
+	if !ok || len(rule.Options) == 0 {
+		return nil
+	}
+
+	b, err := json.Marshal(rule.Options)
+	if err != nil {
+		return fmt.Errorf("rule %q: %w", id, err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+
+	if err = dec.Decode(v); err != nil {
+		return fmt.Errorf("rule %q: bad options: %w", id, err)
+	}
+
+	return nil
+}
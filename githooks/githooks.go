@@ -0,0 +1,395 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+// Package githooks installs git hooks that run commit-tool.
+package githooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-git/v5"
+
+	"codeberg.org/somebadcode/commit-tool/internal/gitconfig"
+)
+
+// Marker is written to every hook that is installed, it identifies the hooks that can be safely removed or replaced.
+const Marker = "# Installed by commit-tool."
+
+// ChainedSuffix is appended to the name of a hook that existed before installing, it's called by the installed hook.
+const ChainedSuffix = ".local"
+
+var (
+	ErrNotFilesystem = errors.New("repository is not stored on a filesystem")
+	ErrCommandEmpty  = errors.New("command is required")
+)
+
+// Hooks are the names of the hooks that are installed.
+var Hooks = []string{
+	"commit-msg",
+	"prepare-commit-msg",
+	"pre-push",
+}
+
+var scripts = template.Must(template.New("hooks").Funcs(template.FuncMap{"shellquote": shellQuote}).Parse(`
+{{- define "header" -}}
+#!/bin/sh
+` + Marker + ` Run "commit-tool hooks uninstall" to remove it.
+{{ end -}}
+
+{{- define "commit-msg" -}}
+{{ template "header" . }}
+chained="$(dirname "$0")/{{ .Name }}{{ .Suffix }}"
+if [ -x "$chained" ]; then
+	"$chained" "$@" || exit $?
+fi
+
+exec {{ shellquote .Command }} lint --message-file "$1"
+{{ end -}}
+
+{{- define "prepare-commit-msg" -}}
+{{ template "header" . }}
+chained="$(dirname "$0")/{{ .Name }}{{ .Suffix }}"
+if [ -x "$chained" ]; then
+	"$chained" "$@" || exit $?
+fi
+
+exec {{ shellquote .Command }} hooks prepare-message "$@"
+{{ end -}}
+
+{{- define "pre-push" -}}
+{{ template "header" . }}
+input="$(cat)"
+
+chained="$(dirname "$0")/{{ .Name }}{{ .Suffix }}"
+if [ -x "$chained" ]; then
+	printf '%s\n' "$input" | "$chained" "$@" || exit $?
+fi
+
+printf '%s\n' "$input" | {{ shellquote .Command }} lint --pre-push
+{{ end -}}
+`))
+
+// shellQuote quotes s as a single word for the shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// State describes what is installed for a hook.
+type State int
+
+const (
+	// NotInstalled means that there's no hook.
+	NotInstalled State = iota
+	// Installed means that the hook was installed by commit-tool.
+	Installed
+	// Foreign means that there's a hook that wasn't installed by commit-tool.
+	Foreign
+)
+
+func (s State) String() string {
+	switch s {
+	case NotInstalled:
+		return "not installed"
+	case Installed:
+		return "installed"
+	case Foreign:
+		return "foreign"
+	}
+
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// Status is the status of a single hook.
+type Status struct {
+	Name  string
+	Path  string
+	State State
+	// Chained is true if there's a hook that will be called by the installed hook.
+	Chained bool
+}
+
+// Installer installs, uninstalls and inspects the hooks in a hooks directory.
+type Installer struct {
+	// Dir is the hooks directory.
+	Dir string
+	// Command is the command that the hooks use to run commit-tool.
+	Command string
+
+	Logger *slog.Logger
+}
+
+func (i *Installer) Validate() error {
+	if i.Command == "" {
+		return ErrCommandEmpty
+	}
+
+	if i.Logger == nil {
+		i.Logger = slog.New(slog.DiscardHandler)
+	}
+
+	return nil
+}
+
+// Install writes the hooks. A hook that wasn't installed by commit-tool is renamed so that it's called by the new hook.
+func (i *Installer) Install(ctx context.Context) error {
+	if err := i.Validate(); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(i.Dir, 0o755); err != nil {
+		return fmt.Errorf("creating hooks directory: %w", err)
+	}
+
+	for _, name := range Hooks {
+		status, err := i.status(name)
+		if err != nil {
+			return err
+		}
+
+		if status.State == Foreign {
+			if status.Chained {
+				return fmt.Errorf("cannot chain hook %q, %q already exists", status.Path, status.Path+ChainedSuffix)
+			}
+
+			if err = os.Rename(status.Path, status.Path+ChainedSuffix); err != nil {
+				return fmt.Errorf("chaining existing hook: %w", err)
+			}
+
+			i.Logger.LogAttrs(ctx, slog.LevelInfo, "chained existing hook",
+				slog.String("hook", name),
+				slog.String("path", status.Path+ChainedSuffix),
+			)
+		}
+
+		var buf bytes.Buffer
+
+		err = scripts.ExecuteTemplate(&buf, name, map[string]string{
+			"Name":    name,
+			"Suffix":  ChainedSuffix,
+			"Command": i.Command,
+		})
+		if err != nil {
+			return fmt.Errorf("rendering hook %q: %w", name, err)
+		}
+
+		if err = os.WriteFile(status.Path, buf.Bytes(), 0o755); err != nil {
+			return fmt.Errorf("writing hook: %w", err)
+		}
+
+		// WriteFile doesn't change the permissions of an existing file.
+		if err = os.Chmod(status.Path, 0o755); err != nil {
+			return fmt.Errorf("making hook executable: %w", err)
+		}
+
+		i.Logger.LogAttrs(ctx, slog.LevelInfo, "installed hook",
+			slog.String("hook", name),
+			slog.String("path", status.Path),
+		)
+	}
+
+	return nil
+}
+
+// Uninstall removes the hooks that were installed by commit-tool and restores the hooks that they chained to.
+func (i *Installer) Uninstall(ctx context.Context) error {
+	if err := i.Validate(); err != nil {
+		return err
+	}
+
+	for _, name := range Hooks {
+		status, err := i.status(name)
+		if err != nil {
+			return err
+		}
+
+		if status.State != Installed {
+			continue
+		}
+
+		if err = os.Remove(status.Path); err != nil {
+			return fmt.Errorf("removing hook: %w", err)
+		}
+
+		i.Logger.LogAttrs(ctx, slog.LevelInfo, "removed hook",
+			slog.String("hook", name),
+			slog.String("path", status.Path),
+		)
+
+		if !status.Chained {
+			continue
+		}
+
+		if err = os.Rename(status.Path+ChainedSuffix, status.Path); err != nil {
+			return fmt.Errorf("restoring chained hook: %w", err)
+		}
+
+		i.Logger.LogAttrs(ctx, slog.LevelInfo, "restored chained hook",
+			slog.String("hook", name),
+			slog.String("path", status.Path),
+		)
+	}
+
+	return nil
+}
+
+// Status returns the status of each hook.
+func (i *Installer) Status() ([]Status, error) {
+	statuses := make([]Status, 0, len(Hooks))
+
+	for _, name := range Hooks {
+		status, err := i.status(name)
+		if err != nil {
+			return nil, err
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (i *Installer) status(name string) (Status, error) {
+	status := Status{
+		Name: name,
+		Path: filepath.Join(i.Dir, name),
+	}
+
+	b, err := os.ReadFile(status.Path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		status.State = NotInstalled
+	case err != nil:
+		return status, fmt.Errorf("reading hook: %w", err)
+	case bytes.Contains(b, []byte(Marker)):
+		status.State = Installed
+	default:
+		status.State = Foreign
+	}
+
+	_, err = os.Stat(status.Path + ChainedSuffix)
+	switch {
+	case err == nil:
+		status.Chained = true
+	case !errors.Is(err, fs.ErrNotExist):
+		return status, fmt.Errorf("checking for chained hook: %w", err)
+	}
+
+	return status, nil
+}
+
+// Dir returns the hooks directory of the repository. It's the directory configured using `core.hooksPath`, in the
+// repository, global or system configuration, or the `hooks` directory in the git directory. Linked worktrees share the
+// hooks directory of the main worktree.
+func Dir(repo *git.Repository) (string, error) {
+	storage, ok := repo.Storer.(interface{ Filesystem() billy.Filesystem })
+	if !ok || storage.Filesystem() == nil {
+		return "", ErrNotFilesystem
+	}
+
+	gitDir := storage.Filesystem().Root()
+
+	commonDir, err := commonDir(gitDir)
+	if err != nil {
+		return "", err
+	}
+
+	hooksPath := gitconfig.Option(repo, "core", "hooksPath")
+	if hooksPath == "" {
+		return filepath.Join(commonDir, "hooks"), nil
+	}
+
+	if strings.HasPrefix(hooksPath, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("expanding hooks path: %w", err)
+		}
+
+		hooksPath = filepath.Join(home, hooksPath[2:])
+	}
+
+	if filepath.IsAbs(hooksPath) {
+		return hooksPath, nil
+	}
+
+	// A relative hooks path is relative to where hooks are run, which is the root of the working tree.
+	tree, err := repo.Worktree()
+	if errors.Is(err, git.ErrIsBareRepository) {
+		return filepath.Join(gitDir, hooksPath), nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(tree.Filesystem.Root(), hooksPath), nil
+}
+
+// commonDir returns the directory that linked worktrees share with the main worktree. It's the git directory itself
+// unless it's the git directory of a linked worktree.
+func commonDir(gitDir string) (string, error) {
+	b, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return gitDir, nil
+	} else if err != nil {
+		return "", fmt.Errorf("reading commondir: %w", err)
+	}
+
+	dir := strings.TrimSpace(string(b))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(gitDir, dir)
+	}
+
+	return filepath.Clean(dir), nil
+}
+
+// PrepareMessage adds the hints as comments to a commit message that git is about to open in an editor. The hints are
+// put before git's own comments so that they're close to where the message is written.
+func PrepareMessage(message string, commentString string, hints []string) string {
+	if len(hints) == 0 {
+		return message
+	}
+
+	var comments strings.Builder
+
+	for _, hint := range hints {
+		comments.WriteString(commentString)
+		comments.WriteByte(' ')
+		comments.WriteString(hint)
+		comments.WriteByte('\n')
+	}
+
+	var sb strings.Builder
+
+	inserted := false
+
+	for line := range strings.Lines(message) {
+		if !inserted && strings.HasPrefix(line, commentString) {
+			sb.WriteString(comments.String())
+			inserted = true
+		}
+
+		sb.WriteString(line)
+	}
+
+	if !inserted {
+		if sb.Len() > 0 && !strings.HasSuffix(sb.String(), "\n") {
+			sb.WriteByte('\n')
+		}
+
+		sb.WriteString(comments.String())
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,319 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package githooks_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/google/go-cmp/cmp"
+
+	"codeberg.org/somebadcode/commit-tool/githooks"
+)
+
+func TestInstaller(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	existing := []byte("#!/bin/sh\necho existing\n")
+
+	if err := os.WriteFile(filepath.Join(dir, "commit-msg"), existing, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	installer := githooks.Installer{
+		Dir:     dir,
+		Command: "commit-tool",
+	}
+
+	if err := installer.Install(t.Context()); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	// Installing twice must not chain the hooks to themselves.
+	if err := installer.Install(t.Context()); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	got, err := installer.Status()
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+
+	want := []githooks.Status{
+		{Name: "commit-msg", Path: filepath.Join(dir, "commit-msg"), State: githooks.Installed, Chained: true},
+		{Name: "prepare-commit-msg", Path: filepath.Join(dir, "prepare-commit-msg"), State: githooks.Installed},
+		{Name: "pre-push", Path: filepath.Join(dir, "pre-push"), State: githooks.Installed},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Status() mismatch (-want +got):\n%s", diff)
+	}
+
+	if err = installer.Uninstall(t.Context()); err != nil {
+		t.Fatalf("Uninstall() error = %v", err)
+	}
+
+	restored, err := os.ReadFile(filepath.Join(dir, "commit-msg"))
+	if err != nil {
+		t.Fatalf("chained hook was not restored: %v", err)
+	}
+
+	if !cmp.Equal(existing, restored) {
+		t.Errorf("restored hook = %q, want %q", restored, existing)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 {
+		t.Errorf("Uninstall() left %d files, want 1", len(entries))
+	}
+}
+
+func TestInstaller_commandWithSpaces(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("hooks are shell scripts")
+	}
+
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "my tools", "it's commit-tool")
+	out := filepath.Join(dir, "args")
+
+	if err := os.MkdirAll(filepath.Dir(bin), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(bin, []byte("#!/bin/sh\necho \"$@\" > '"+out+"'\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	installer := githooks.Installer{
+		Dir:     filepath.Join(dir, "hooks"),
+		Command: bin,
+	}
+
+	if err := installer.Install(t.Context()); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	if b, err := exec.Command(filepath.Join(installer.Dir, "commit-msg"), "MSG").CombinedOutput(); err != nil {
+		t.Fatalf("running hook: %v: %s", err, b)
+	}
+
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "lint --message-file MSG\n"; string(got) != want {
+		t.Errorf("hook ran command with %q, want %q", got, want)
+	}
+}
+
+func TestDir_globalHooksPath(t *testing.T) {
+	home := t.TempDir()
+	hooks := filepath.Join(home, "hooks")
+
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+
+	if err := os.WriteFile(filepath.Join(home, ".gitconfig"), []byte("[core]\n\thooksPath = "+hooks+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := git.PlainInit(t.TempDir(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := githooks.Dir(repo)
+	if err != nil {
+		t.Fatalf("Dir() error = %v", err)
+	}
+
+	if got != hooks {
+		t.Errorf("Dir() = %q, want %q", got, hooks)
+	}
+}
+
+func TestPrepareMessage(t *testing.T) {
+	type args struct {
+		message string
+		hints   []string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "before_comments",
+			args: args{
+				message: "\n# Please enter the commit message for your changes.\n",
+				hints:   []string{"Allowed types: feat, fix"},
+			},
+			want: "\n# Allowed types: feat, fix\n# Please enter the commit message for your changes.\n",
+		},
+		{
+			name: "no_comments",
+			args: args{
+				message: "feat: add foo",
+				hints:   []string{"Allowed types: feat, fix"},
+			},
+			want: "feat: add foo\n# Allowed types: feat, fix\n",
+		},
+		{
+			name: "no_hints",
+			args: args{
+				message: "feat: add foo",
+			},
+			want: "feat: add foo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := githooks.PrepareMessage(tt.args.message, "#", tt.args.hints); got != tt.want {
+				t.Errorf("PrepareMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadPushUpdates(t *testing.T) {
+	const (
+		zero  = "0000000000000000000000000000000000000000"
+		local = "1111111111111111111111111111111111111111"
+		other = "2222222222222222222222222222222222222222"
+	)
+
+	tests := []struct {
+		name    string
+		input   string
+		want    []githooks.PushUpdate
+		wantErr bool
+	}{
+		{
+			name:  "update_create_delete",
+			input: "refs/heads/a " + local + " refs/heads/a " + other + "\nrefs/heads/b " + local + " refs/heads/b " + zero + "\n(delete) " + zero + " refs/heads/c " + other + "\n\n",
+			want: []githooks.PushUpdate{
+				{LocalRef: "refs/heads/a", LocalHash: plumbing.NewHash(local), RemoteRef: "refs/heads/a", RemoteHash: plumbing.NewHash(other)},
+				{LocalRef: "refs/heads/b", LocalHash: plumbing.NewHash(local), RemoteRef: "refs/heads/b"},
+				{LocalRef: "(delete)", RemoteRef: "refs/heads/c", RemoteHash: plumbing.NewHash(other)},
+			},
+		},
+		{
+			name:  "empty",
+			input: "",
+		},
+		{
+			name:    "missing_field",
+			input:   "refs/heads/a " + local + " refs/heads/a\n",
+			wantErr: true,
+		},
+		{
+			name:    "bad_hash",
+			input:   "refs/heads/a HEAD refs/heads/a " + other + "\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := githooks.ReadPushUpdates(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadPushUpdates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ReadPushUpdates() mismatch (-want +got):\n%s", diff)
+			}
+
+			for _, update := range got {
+				if update.IsDelete() && update.IsCreate() {
+					t.Errorf("update %v is both a create and a delete", update)
+				}
+			}
+		})
+	}
+}
+
+func TestReadReceiveUpdates(t *testing.T) {
+	const (
+		zero   = "0000000000000000000000000000000000000000"
+		before = "1111111111111111111111111111111111111111"
+		after  = "2222222222222222222222222222222222222222"
+	)
+
+	tests := []struct {
+		name    string
+		input   string
+		want    []githooks.ReceiveUpdate
+		wantErr bool
+	}{
+		{
+			name:  "update_create_delete",
+			input: before + " " + after + " refs/heads/a\n" + zero + " " + after + " refs/heads/b\n\n" + before + " " + zero + " refs/tags/c\n",
+			want: []githooks.ReceiveUpdate{
+				{Old: plumbing.NewHash(before), New: plumbing.NewHash(after), Ref: "refs/heads/a"},
+				{New: plumbing.NewHash(after), Ref: "refs/heads/b"},
+				{Old: plumbing.NewHash(before), Ref: "refs/tags/c"},
+			},
+		},
+		{
+			name:  "empty",
+			input: "",
+		},
+		{
+			name:    "missing_field",
+			input:   before + " " + after + "\n",
+			wantErr: true,
+		},
+		{
+			name:    "bad_hash",
+			input:   before + " main refs/heads/a\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := githooks.ReadReceiveUpdates(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadReceiveUpdates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ReadReceiveUpdates() mismatch (-want +got):\n%s", diff)
+			}
+
+			for _, update := range got {
+				if update.IsDelete() && update.IsCreate() {
+					t.Errorf("update %v is both a create and a delete", update)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package githooks
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+var (
+	ErrBadUpdateLine = errors.New("bad ref update line")
+)
+
+// PushUpdate is a ref update that git passes to the pre-push hook.
+type PushUpdate struct {
+	LocalRef   plumbing.ReferenceName
+	LocalHash  plumbing.Hash
+	RemoteRef  plumbing.ReferenceName
+	RemoteHash plumbing.Hash
+}
+
+// IsDelete reports if the update deletes the remote ref.
+func (u PushUpdate) IsDelete() bool {
+	return u.LocalHash.IsZero()
+}
+
+// IsCreate reports if the update creates the remote ref.
+func (u PushUpdate) IsCreate() bool {
+	return u.RemoteHash.IsZero()
+}
+
+// ReadPushUpdates reads the `<local ref> <local sha> <remote ref> <remote sha>` lines that git writes to the standard
+// input of the pre-push hook.
+func ReadPushUpdates(r io.Reader) ([]PushUpdate, error) {
+	var updates []PushUpdate
+
+	err := readFields(r, 4, func(fields []string) error {
+		localHash, err := parseHash(fields[1])
+		if err != nil {
+			return err
+		}
+
+		remoteHash, err := parseHash(fields[3])
+		if err != nil {
+			return err
+		}
+
+		updates = append(updates, PushUpdate{
+			LocalRef:   plumbing.ReferenceName(fields[0]),
+			LocalHash:  localHash,
+			RemoteRef:  plumbing.ReferenceName(fields[2]),
+			RemoteHash: remoteHash,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updates, nil
+}
+
+// ReceiveUpdate is a ref update that git passes to the pre-receive and update hooks.
+type ReceiveUpdate struct {
+	Old plumbing.Hash
+	New plumbing.Hash
+	Ref plumbing.ReferenceName
+}
+
+// IsDelete reports if the update deletes the ref.
+func (u ReceiveUpdate) IsDelete() bool {
+	return u.New.IsZero()
+}
+
+// IsCreate reports if the update creates the ref.
+func (u ReceiveUpdate) IsCreate() bool {
+	return u.Old.IsZero()
+}
+
+// ReadReceiveUpdates reads the `<old sha> <new sha> <ref>` lines that git writes to the standard input of the
+// pre-receive hook.
+func ReadReceiveUpdates(r io.Reader) ([]ReceiveUpdate, error) {
+	var updates []ReceiveUpdate
+
+	err := readFields(r, 3, func(fields []string) error {
+		update, err := ParseReceiveUpdate(fields[2], fields[0], fields[1])
+		if err != nil {
+			return err
+		}
+
+		updates = append(updates, update)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updates, nil
+}
+
+// ParseReceiveUpdate parses the arguments that git passes to the update hook.
+func ParseReceiveUpdate(ref, oldHash, newHash string) (ReceiveUpdate, error) {
+	o, err := parseHash(oldHash)
+	if err != nil {
+		return ReceiveUpdate{}, err
+	}
+
+	n, err := parseHash(newHash)
+	if err != nil {
+		return ReceiveUpdate{}, err
+	}
+
+	return ReceiveUpdate{
+		Old: o,
+		New: n,
+		Ref: plumbing.ReferenceName(ref),
+	}, nil
+}
+
+func readFields(r io.Reader, n int, fn func(fields []string) error) error {
+	scanner := bufio.NewScanner(r)
+
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		if len(fields) != n {
+			return fmt.Errorf("line %d: expected %d fields but got %d: %w", line, n, len(fields), ErrBadUpdateLine)
+		}
+
+		if err := fn(fields); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading ref updates: %w", err)
+	}
+
+	return nil
+}
+
+func parseHash(s string) (plumbing.Hash, error) {
+	if !plumbing.IsHash(s) {
+		return plumbing.ZeroHash, fmt.Errorf("bad object name %q: %w", s, ErrBadUpdateLine)
+	}
+
+	return plumbing.NewHash(s), nil
+}
@@ -0,0 +1,187 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+// Package changes lists the files that commits change, including the commit of a message that is being linted before
+// it's committed.
+package changes
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// ErrNotCommitted is returned for a commit that hasn't been created yet, like the one of a message that is being linted
+// before it's committed. Such a commit has no tree to diff.
+var ErrNotCommitted = errors.New("commit hasn't been created yet")
+
+// Files returns the sorted paths of the files that the commit changes compared to its first parent. Every file is
+// changed by a commit without parents. Both paths of a renamed file are returned.
+func Files(commit *object.Commit) ([]string, error) {
+	if commit.Hash.IsZero() {
+		return nil, ErrNotCommitted
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree of commit %s: %w", commit.Hash, err)
+	}
+
+	var parentTree *object.Tree
+
+	if commit.NumParents() > 0 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent of commit %s: %w", commit.Hash, err)
+		}
+
+		if parentTree, err = parent.Tree(); err != nil {
+			return nil, fmt.Errorf("failed to get tree of commit %s: %w", parent.Hash, err)
+		}
+	}
+
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff commit %s: %w", commit.Hash, err)
+	}
+
+	var files []string
+
+	for _, change := range changes {
+		for _, name := range []string{change.From.Name, change.To.Name} {
+			if name != "" {
+				files = append(files, name)
+			}
+		}
+	}
+
+	slices.Sort(files)
+
+	return slices.Compact(files), nil
+}
+
+// Staged returns the sorted paths of the files that are staged to be committed, which are the files that differ
+// between the index and the tree of HEAD. Like git, it reads the index that GIT_INDEX_FILE names, if set, e.g. the
+// temporary index of git commit --all or git commit with paths.
+func Staged(repo *git.Repository) ([]string, error) {
+	idx, err := readIndex(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	staged := make(map[string]*index.Entry, len(idx.Entries))
+
+	for _, entry := range idx.Entries {
+		if !entry.IntentToAdd {
+			staged[entry.Name] = entry
+		}
+	}
+
+	var files []string
+
+	head, err := repo.Head()
+
+	switch {
+	case errors.Is(err, plumbing.ErrReferenceNotFound):
+		// Every staged file is added by the initial commit.
+	case err != nil:
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	default:
+		commit, err := repo.CommitObject(head.Hash())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get commit %s: %w", head.Hash(), err)
+		}
+
+		tree, err := commit.Tree()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tree of commit %s: %w", commit.Hash, err)
+		}
+
+		walker := object.NewTreeWalker(tree, true, nil)
+		defer walker.Close()
+
+		for {
+			name, entry, err := walker.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return nil, fmt.Errorf("failed to walk tree of commit %s: %w", commit.Hash, err)
+			}
+
+			if entry.Mode == filemode.Dir {
+				continue
+			}
+
+			if indexEntry, found := staged[name]; !found || indexEntry.Hash != entry.Hash || indexEntry.Mode != entry.Mode {
+				files = append(files, name)
+			}
+
+			delete(staged, name)
+		}
+	}
+
+	// The files that are left are only in the index.
+	for name := range staged {
+		files = append(files, name)
+	}
+
+	slices.Sort(files)
+
+	return files, nil
+}
+
+// Commit returns the sorted paths of the files that the commit changes, see [Files]. The files of a commit that hasn't
+// been created yet are the files that are staged in the repository, see [Staged], unless there's no repository.
+func Commit(repo *git.Repository, commit *object.Commit) ([]string, error) {
+	if !commit.Hash.IsZero() {
+		return Files(commit)
+	}
+
+	if repo == nil {
+		return nil, ErrNotCommitted
+	}
+
+	return Staged(repo)
+}
+
+// readIndex reads the index that GIT_INDEX_FILE names, or the index of the repository.
+func readIndex(repo *git.Repository) (*index.Index, error) {
+	filename := os.Getenv("GIT_INDEX_FILE")
+	if filename == "" {
+		idx, err := repo.Storer.Index()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read index: %w", err)
+		}
+
+		return idx, nil
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index: %w", err)
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	idx := &index.Index{}
+
+	if err = index.NewDecoder(f).Decode(idx); err != nil {
+		return nil, fmt.Errorf("failed to read index %s: %w", filename, err)
+	}
+
+	return idx, nil
+}
@@ -0,0 +1,151 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package changes_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/google/go-cmp/cmp"
+
+	"codeberg.org/somebadcode/commit-tool/internal/changes"
+	"codeberg.org/somebadcode/commit-tool/internal/repobuilder"
+)
+
+var commitOpts = git.CommitOptions{
+	Author: &object.Signature{
+		Name:  "Gopher",
+		Email: "gopher@example.com",
+		When:  time.Date(2023, 2, 4, 23, 22, 0, 0, time.UTC),
+	},
+}
+
+func TestStaged(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		ops  []repobuilder.OperationFunc
+		want []string
+	}{
+		{
+			name: "initial",
+			ops: []repobuilder.OperationFunc{
+				repobuilder.WriteFile("README.md", "Services\n"),
+			},
+			want: []string{"README.md"},
+		},
+		{
+			name: "nothing_staged",
+			ops: []repobuilder.OperationFunc{
+				repobuilder.WriteFile("README.md", "Services\n"),
+				repobuilder.Commit("docs: add readme", commitOpts),
+			},
+		},
+		{
+			name: "added_and_modified",
+			ops: []repobuilder.OperationFunc{
+				repobuilder.WriteFile("README.md", "Services\n"),
+				repobuilder.WriteFile("go.mod", "module example.com/services\n"),
+				repobuilder.Commit("docs: add readme", commitOpts),
+				repobuilder.WriteFile("services/api/main.go", "package main\n"),
+				repobuilder.WriteFile("README.md", "The services.\n"),
+			},
+			want: []string{"README.md", "services/api/main.go"},
+		},
+		{
+			name: "removed",
+			ops: []repobuilder.OperationFunc{
+				repobuilder.WriteFile("README.md", "Services\n"),
+				repobuilder.WriteFile("go.mod", "module example.com/services\n"),
+				repobuilder.Commit("docs: add readme", commitOpts),
+				func(_ *git.Repository, worktree *git.Worktree) error {
+					_, err := worktree.Remove("go.mod")
+					return err
+				},
+			},
+			want: []string{"go.mod"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo, err := repobuilder.Build(tt.ops...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := changes.Staged(repo)
+			if err != nil {
+				t.Fatalf("Staged() error = %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Staged() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestStaged_indexFile can't run in parallel since it sets GIT_INDEX_FILE.
+func TestStaged_indexFile(t *testing.T) {
+	repo, err := repobuilder.Build(
+		repobuilder.WriteFile("README.md", "Services\n"),
+		repobuilder.Commit("docs: add readme", commitOpts),
+		repobuilder.WriteFile("go.mod", "module example.com/services\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Like the temporary index of git commit --all, the index file has other changes than the repository's index.
+	idx, err := repo.Storer.Index()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry, err := idx.Entry("go.mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry.Name = "services/api/go.mod"
+
+	filename := filepath.Join(t.TempDir(), "index")
+
+	f, err := os.Create(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = index.NewEncoder(f).Encode(idx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("GIT_INDEX_FILE", filename)
+
+	got, err := changes.Staged(repo)
+	if err != nil {
+		t.Fatalf("Staged() error = %v", err)
+	}
+
+	if diff := cmp.Diff([]string{"services/api/go.mod"}, got); diff != "" {
+		t.Errorf("Staged() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,37 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+// Package gitconfig looks up git configuration options the way git does.
+package gitconfig
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+)
+
+// Option looks up an option in the repository, global and system configuration, in that order. The repository may be
+// nil, in which case only the global and system configuration are used.
+func Option(repo *git.Repository, section string, key string) string {
+	if repo != nil {
+		if cfg, err := repo.Config(); err == nil && cfg.Raw.Section(section).HasOption(key) {
+			return cfg.Raw.Section(section).Option(key)
+		}
+	}
+
+	for _, scope := range []config.Scope{config.GlobalScope, config.SystemScope} {
+		cfg, err := config.LoadConfig(scope)
+		if err != nil {
+			continue
+		}
+
+		if cfg.Raw.Section(section).HasOption(key) {
+			return cfg.Raw.Section(section).Option(key)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,167 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+// Package objectdirs opens a repository with the object directories that git sets using the environment variables
+// GIT_OBJECT_DIRECTORY and GIT_ALTERNATE_OBJECT_DIRECTORIES. While running the pre-receive and update hooks, git
+// quarantines the objects that are being pushed: GIT_QUARANTINE_PATH and GIT_OBJECT_DIRECTORY name the quarantine
+// directory, and the object directory of the repository is an alternate, so that the hooks can see the pushed objects
+// before they have been accepted.
+package objectdirs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/helper/mount"
+	"github.com/go-git/go-billy/v5/helper/polyfill"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/cache"
+	"github.com/go-git/go-git/v5/storage"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+	"github.com/go-git/go-git/v5/storage/filesystem/dotgit"
+)
+
+// Dirs are the object directories of a repository.
+type Dirs struct {
+	// Objects replaces the object directory of the repository, like GIT_OBJECT_DIRECTORY, unless it's empty.
+	Objects string
+	// Alternates are looked up after the object directory, like GIT_ALTERNATE_OBJECT_DIRECTORIES.
+	Alternates []string
+}
+
+// IsZero reports if the repository's own object directory is the only one.
+func (dirs Dirs) IsZero() bool {
+	return dirs.Objects == "" && len(dirs.Alternates) == 0
+}
+
+// FromEnvironment returns the object directories that are set using environment variables.
+func FromEnvironment() Dirs {
+	var dirs Dirs
+
+	dirs.Objects = os.Getenv("GIT_OBJECT_DIRECTORY")
+
+	for _, dir := range filepath.SplitList(os.Getenv("GIT_ALTERNATE_OBJECT_DIRECTORIES")) {
+		if dir != "" {
+			dirs.Alternates = append(dirs.Alternates, dir)
+		}
+	}
+
+	return dirs
+}
+
+// Storage looks up objects in the object directories, in order, and everything else in the repository's storage.
+type Storage struct {
+	storage.Storer
+
+	objectDirs []objectStorer
+}
+
+// objectStorer looks up objects, like the object storage of a directory.
+type objectStorer interface {
+	EncodedObject(t plumbing.ObjectType, h plumbing.Hash) (plumbing.EncodedObject, error)
+	HasEncodedObject(h plumbing.Hash) error
+	EncodedObjectSize(h plumbing.Hash) (int64, error)
+}
+
+// Open reopens the repository with the object directories. Relative directories are relative to the current working
+// directory. The repository is returned as it is if the object directories are zero.
+func Open(repo *git.Repository, dirs Dirs) (*git.Repository, error) {
+	if dirs.IsZero() {
+		return repo, nil
+	}
+
+	s := &Storage{
+		Storer: repo.Storer,
+	}
+
+	if dirs.Objects == "" {
+		s.objectDirs = append(s.objectDirs, repo.Storer)
+	} else {
+		objectDir, err := objectStorage(dirs.Objects)
+		if err != nil {
+			return nil, err
+		}
+
+		s.objectDirs = append(s.objectDirs, objectDir)
+	}
+
+	for _, dir := range dirs.Alternates {
+		objectDir, err := objectStorage(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		s.objectDirs = append(s.objectDirs, objectDir)
+	}
+
+	tree, err := repo.Worktree()
+	if errors.Is(err, git.ErrIsBareRepository) {
+		return git.Open(s, nil)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return git.Open(s, tree.Filesystem)
+}
+
+// objectStorage returns the storage of the objects in the directory.
+func objectStorage(dir string) (*filesystem.ObjectStorage, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// The object storage expects a git directory, so mount the object directory where it would be in one.
+	fs := polyfill.New(mount.New(memfs.New(), "objects", osfs.New(dir)))
+
+	return filesystem.NewObjectStorage(dotgit.New(fs), cache.NewObjectLRUDefault()), nil
+}
+
+// Filesystem returns the filesystem of the repository's storage, or nil if the repository isn't stored on a filesystem.
+func (s *Storage) Filesystem() billy.Filesystem {
+	if fs, ok := s.Storer.(interface{ Filesystem() billy.Filesystem }); ok {
+		return fs.Filesystem()
+	}
+
+	return nil
+}
+
+func (s *Storage) EncodedObject(t plumbing.ObjectType, h plumbing.Hash) (plumbing.EncodedObject, error) {
+	for _, objectDir := range s.objectDirs {
+		obj, err := objectDir.EncodedObject(t, h)
+		if !errors.Is(err, plumbing.ErrObjectNotFound) {
+			return obj, err
+		}
+	}
+
+	return nil, plumbing.ErrObjectNotFound
+}
+
+func (s *Storage) HasEncodedObject(h plumbing.Hash) error {
+	for _, objectDir := range s.objectDirs {
+		if err := objectDir.HasEncodedObject(h); !errors.Is(err, plumbing.ErrObjectNotFound) {
+			return err
+		}
+	}
+
+	return plumbing.ErrObjectNotFound
+}
+
+func (s *Storage) EncodedObjectSize(h plumbing.Hash) (int64, error) {
+	for _, objectDir := range s.objectDirs {
+		if size, err := objectDir.EncodedObjectSize(h); !errors.Is(err, plumbing.ErrObjectNotFound) {
+			return size, err
+		}
+	}
+
+	return 0, plumbing.ErrObjectNotFound
+}
@@ -0,0 +1,145 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package objectdirs_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/cache"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+
+	"codeberg.org/somebadcode/commit-tool/internal/objectdirs"
+	"codeberg.org/somebadcode/commit-tool/internal/repobuilder"
+)
+
+// TestOpen can't run in parallel since it sets the environment variables of the object directories.
+func TestOpen(t *testing.T) {
+	gopher := &object.Signature{
+		Name:  "Gopher",
+		Email: "gopher@example.com",
+		When:  time.Date(2023, 2, 4, 23, 22, 0, 0, time.UTC),
+	}
+
+	dir := t.TempDir()
+
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = repobuilder.Commit("chore: init", git.CommitOptions{AllowEmptyCommits: true, Author: gopher})(repo, worktree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	initial, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Like git receive-pack, store the pushed commit in a quarantine directory only.
+	incoming := t.TempDir()
+	quarantine := filepath.Join(incoming, "objects")
+
+	pushed := &object.Commit{
+		Author:       *gopher,
+		Committer:    *gopher,
+		Message:      "feat: add foo\n",
+		TreeHash:     initial.TreeHash,
+		ParentHashes: []plumbing.Hash{initial.Hash},
+	}
+
+	quarantineStorage := filesystem.NewStorage(osfs.New(incoming), cache.NewObjectLRUDefault())
+
+	obj := quarantineStorage.NewEncodedObject()
+	if err = pushed.Encode(obj); err != nil {
+		t.Fatal(err)
+	}
+
+	if pushed.Hash, err = quarantineStorage.SetEncodedObject(obj); err != nil {
+		t.Fatal(err)
+	}
+
+	objects := filepath.Join(dir, git.GitDirName, "objects")
+
+	tests := []struct {
+		name        string
+		env         map[string]string
+		wantInitial bool
+		wantPushed  bool
+	}{
+		{
+			name:        "none",
+			wantInitial: true,
+		},
+		{
+			// The environment of the pre-receive and update hooks.
+			name: "quarantine",
+			env: map[string]string{
+				"GIT_QUARANTINE_PATH":              quarantine,
+				"GIT_OBJECT_DIRECTORY":             quarantine,
+				"GIT_ALTERNATE_OBJECT_DIRECTORIES": objects,
+			},
+			wantInitial: true,
+			wantPushed:  true,
+		},
+		{
+			// The object directory replaces the repository's own.
+			name: "object_directory",
+			env: map[string]string{
+				"GIT_OBJECT_DIRECTORY": quarantine,
+			},
+			wantPushed: true,
+		},
+		{
+			name: "alternates",
+			env: map[string]string{
+				"GIT_ALTERNATE_OBJECT_DIRECTORIES": quarantine,
+			},
+			wantInitial: true,
+			wantPushed:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"GIT_QUARANTINE_PATH", "GIT_OBJECT_DIRECTORY", "GIT_ALTERNATE_OBJECT_DIRECTORIES"} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			reopened, err := objectdirs.Open(repo, objectdirs.FromEnvironment())
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+
+			if _, err = reopened.CommitObject(initial.Hash); (err == nil) != tt.wantInitial {
+				t.Errorf("CommitObject() of the initial commit error = %v, want found %t", err, tt.wantInitial)
+			}
+
+			if _, err = reopened.CommitObject(pushed.Hash); (err == nil) != tt.wantPushed {
+				t.Errorf("CommitObject() of the pushed commit error = %v, want found %t", err, tt.wantPushed)
+			}
+		})
+	}
+}
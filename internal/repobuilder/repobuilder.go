@@ -13,6 +13,7 @@ import (
 
 	"github.com/go-git/go-billy/v5"
 	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/cache"
@@ -109,3 +110,18 @@ func Commit(message string, options git.CommitOptions) OperationFunc {
 		return nil
 	}
 }
+
+// WriteFile writes the file in the worktree and adds it to the index.
+func WriteFile(name string, content string) OperationFunc {
+	return func(_ *git.Repository, worktree *git.Worktree) error {
+		if err := util.WriteFile(worktree.Filesystem, name, []byte(content), 0o644); err != nil {
+			return fmt.Errorf("failed to write file %q: %w", name, err)
+		}
+
+		if _, err := worktree.Add(name); err != nil {
+			return fmt.Errorf("failed to add file %q: %w", name, err)
+		}
+
+		return nil
+	}
+}
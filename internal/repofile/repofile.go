@@ -0,0 +1,63 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+// Package repofile reads files of a repository that configure it, like .mailmap, from the worktree or from HEAD of a
+// bare repository.
+package repofile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Open opens the file, a slash-separated path relative to the root of the repository, from the worktree or, if the
+// repository is bare, from HEAD. The error is [os.ErrNotExist] if there's no such file.
+func Open(repo *git.Repository, name string) (io.ReadCloser, error) {
+	if worktree, err := repo.Worktree(); err == nil {
+		f, err := worktree.Filesystem.Open(filepath.FromSlash(name))
+		if pathErr := (*fs.PathError)(nil); errors.As(err, &pathErr) {
+			// The error already tells which file.
+			return nil, err
+		} else if err != nil {
+			return nil, fmt.Errorf("opening %s: %w", filepath.Join(worktree.Filesystem.Root(), name), err)
+		}
+
+		return f, nil
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		// An empty repository has no files.
+		return nil, fmt.Errorf("reading %s of HEAD: %w", name, os.ErrNotExist)
+	}
+
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("reading HEAD: %w", err)
+	}
+
+	file, err := commit.File(name)
+	if errors.Is(err, object.ErrFileNotFound) {
+		return nil, fmt.Errorf("reading %s of HEAD: %w", name, os.ErrNotExist)
+	} else if err != nil {
+		return nil, fmt.Errorf("reading %s of HEAD: %w", name, err)
+	}
+
+	r, err := file.Reader()
+	if err != nil {
+		return nil, fmt.Errorf("reading %s of HEAD: %w", name, err)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,61 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+// Package textwidth measures text in terminal columns rather than bytes or runes.
+package textwidth
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+)
+
+// RuneWidth returns the number of columns that a terminal uses for the rune. Wide characters, like CJK and most emoji,
+// use two columns. Combining marks and format characters, like zero width joiners and variation selectors, use none.
+func RuneWidth(r rune) int {
+	switch {
+	case r == utf8.RuneError, unicode.IsControl(r):
+		return 1
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	}
+
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+
+	return 1
+}
+
+// Width returns the number of columns that a terminal uses for the string.
+func Width(s string) int {
+	n := 0
+
+	for _, r := range s {
+		n += RuneWidth(r)
+	}
+
+	return n
+}
+
+// Truncate returns the byte offset of the first rune that doesn't fit within the number of columns, or the length of
+// the string if it fits.
+func Truncate(s string, columns int) int {
+	n := 0
+
+	for i, r := range s {
+		n += RuneWidth(r)
+
+		if n > columns {
+			return i
+		}
+	}
+
+	return len(s)
+}
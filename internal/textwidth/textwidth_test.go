@@ -0,0 +1,71 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package textwidth_test
+
+import (
+	"testing"
+
+	"codeberg.org/somebadcode/commit-tool/internal/textwidth"
+)
+
+func TestWidth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		s            string
+		want         int
+		wantTruncate int
+	}{
+		{
+			name:         "ascii",
+			s:            "fix: typo",
+			want:         9,
+			wantTruncate: 5,
+		},
+		{
+			name:         "cjk",
+			s:            "修正バグ",
+			want:         8,
+			wantTruncate: 6,
+		},
+		{
+			name:         "emoji",
+			s:            "🎉 party",
+			want:         8,
+			wantTruncate: 7,
+		},
+		{
+			name:         "combining",
+			s:            "cafe\u0301s",
+			want:         5,
+			wantTruncate: 7,
+		},
+		{
+			name:         "zwj_sequence",
+			s:            "👩‍💻",
+			want:         4,
+			wantTruncate: 11,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := textwidth.Width(tt.s); got != tt.want {
+				t.Errorf("Width() = %d, want %d", got, tt.want)
+			}
+
+			// The offset is of the rune that makes the string wider than 5 columns, or the string's length.
+			if got := textwidth.Truncate(tt.s, 5); got != tt.wantTruncate {
+				t.Errorf("Truncate() = %d, want %d", got, tt.wantTruncate)
+			}
+		})
+	}
+}
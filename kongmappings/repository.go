@@ -8,6 +8,7 @@
 package kongmappings
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 
@@ -23,7 +24,18 @@ func Repository() kong.MapperFunc {
 			return fmt.Errorf("cannot decode repository path: %w", err)
 		}
 
-		repo, err := git.PlainOpen(repoPath)
+		// The path is either the root of a working tree, a bare repository, or anywhere within a working tree which may
+		// be a linked worktree. Detection has to be tried last since it would skip past a bare repository.
+		repo, err := git.PlainOpenWithOptions(repoPath, &git.PlainOpenOptions{
+			EnableDotGitCommonDir: true,
+		})
+		if errors.Is(err, git.ErrRepositoryNotExists) {
+			repo, err = git.PlainOpenWithOptions(repoPath, &git.PlainOpenOptions{
+				DetectDotGit:          true,
+				EnableDotGitCommonDir: true,
+			})
+		}
+
 		if err != nil {
 			return fmt.Errorf("cannot open repository %q: %w", repoPath, err)
 		}
@@ -11,12 +11,28 @@ import (
 	"fmt"
 
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 )
 
 type LintError struct {
 	Err  error
 	Hash plumbing.Hash
-	Pos  int
+	// Pos is the byte offset into the commit message where the error was found.
+	Pos int
+	// Rule is the ID of the rule that failed, if known.
+	Rule     string
+	Severity Severity
+	// Suppressed is set if the commit message suppresses the rule, the error is then only reported.
+	Suppressed bool
+	// Suggestion tells how to fix the error, if the rule knows.
+	Suggestion string
+	// Commit is the commit whose message was linted, if known.
+	Commit *object.Commit
+}
+
+// Fails reports if the error makes linting fail, which only errors that aren't suppressed do.
+func (err LintError) Fails() bool {
+	return err.Severity == SeverityError && !err.Suppressed
 }
 
 func (err LintError) Unwrap() error {
@@ -32,7 +48,17 @@ type Error struct {
 }
 
 func (e *Error) Error() string {
-	return fmt.Sprintf("%d violations found", len(e.errs))
+	violations := 0
+
+	for _, err := range e.errs {
+		for _, lintError := range LintErrors(err) {
+			if lintError.Fails() {
+				violations++
+			}
+		}
+	}
+
+	return fmt.Sprintf("%d violations found", violations)
 }
 
 func (e *Error) Unwrap() []error {
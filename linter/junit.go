@@ -0,0 +1,137 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package linter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string         `xml:"name,attr"`
+	ClassName string         `xml:"classname,attr"`
+	Failures  []junitFailure `xml:"failure"`
+	Errors    []junitFailure `xml:"error"`
+	SystemOut string         `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the report as JUnit XML. Each range of commits is a test suite, even if it has no commits, and
+// each commit is a test case. Errors are failures of the test case, other violations are written to the test case's
+// output. Violations that aren't of any rule mean that the commit couldn't be linted, they are errors of the test case.
+func WriteJUnit(w io.Writer, report Report) error {
+	suites := junitTestSuites{
+		Name: toolName,
+	}
+
+	indices := make(map[string]int)
+
+	suiteOf := func(name string) *junitTestSuite {
+		i, found := indices[name]
+		if !found {
+			i = len(suites.Suites)
+			indices[name] = i
+			suites.Suites = append(suites.Suites, junitTestSuite{
+				Name: name,
+			})
+		}
+
+		return &suites.Suites[i]
+	}
+
+	for _, name := range report.Ranges {
+		suiteOf(name)
+	}
+
+	for _, result := range report.Results {
+		suite := suiteOf(result.Range)
+
+		testCase := junitTestCase{
+			Name:      strings.TrimSpace(result.ShortHash + " " + result.Subject),
+			ClassName: result.Range,
+		}
+
+		var out strings.Builder
+
+		for _, violation := range result.Violations {
+			text := fmt.Sprintf("line %d, column %d: %s", violation.Line, violation.Column, violation.text())
+
+			switch {
+			case !violation.Fails():
+				_, _ = fmt.Fprintf(&out, "%s: %s\n", violation.label(), text)
+			case violation.Rule == "":
+				testCase.Errors = append(testCase.Errors, junitFailure{
+					Message: violation.Message,
+					Text:    text,
+				})
+			default:
+				testCase.Failures = append(testCase.Failures, junitFailure{
+					Message: violation.Message,
+					Type:    violation.Rule,
+					Text:    text,
+				})
+			}
+		}
+
+		testCase.SystemOut = out.String()
+
+		suite.Tests++
+		suites.Tests++
+
+		// A test case is counted once, as an error if it couldn't be linted.
+		switch {
+		case len(testCase.Errors) > 0:
+			suite.Errors++
+			suites.Errors++
+		case len(testCase.Failures) > 0:
+			suite.Failures++
+			suites.Failures++
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
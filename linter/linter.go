@@ -22,6 +22,7 @@ import (
 
 type ReportFunc func(ctx context.Context, err error)
 type StopFunc func(commit *object.Commit) bool
+type PassFunc func(ctx context.Context, commit *object.Commit)
 
 type CommitLinter interface {
 	Lint(*object.Commit) error
@@ -36,10 +37,14 @@ type Linter struct {
 	OtherRev plumbing.Revision
 	// ReportFunc is called after each call to CommitLinter if it returned an error.
 	ReportFunc ReportFunc
+	// PassFunc is called after each call to CommitLinter if it didn't return an error.
+	PassFunc PassFunc
 	// CommitLinter is called for each commit.
 	CommitLinter CommitLinter
 	// StopFunc is called before CommitLinter is called. Determines if the traversal should stop.
 	StopFunc StopFunc
+	// Exclude are commits that are neither linted nor traversed, see [Reachable].
+	Exclude map[plumbing.Hash]bool
 
 	Logger *slog.Logger
 }
@@ -55,8 +60,8 @@ func (l *Linter) Validate() error {
 		return ErrRepositoryRequired
 	}
 
-	if l.CommitLinter == nil {
-		return ErrNoLinter
+	if err := l.validateLinting(); err != nil {
+		return err
 	}
 
 	if l.Rev == "" {
@@ -72,12 +77,25 @@ func (l *Linter) Validate() error {
 		l.StopFunc = stopFunc
 	}
 
+	if l.StopFunc == nil {
+		l.StopFunc = NoStop()
+	}
+
+	return nil
+}
+
+// validateLinting verifies and sets the default values that are needed to lint a commit, with or without a repository.
+func (l *Linter) validateLinting() error {
+	if l.CommitLinter == nil {
+		return ErrNoLinter
+	}
+
 	if l.ReportFunc == nil {
 		l.ReportFunc = NoReporting
 	}
 
-	if l.StopFunc == nil {
-		l.StopFunc = NoStop()
+	if l.PassFunc == nil {
+		l.PassFunc = func(_ context.Context, _ *object.Commit) {}
 	}
 
 	if l.Logger == nil {
@@ -87,6 +105,34 @@ func (l *Linter) Validate() error {
 	return nil
 }
 
+// LintCommit lints a single commit without traversing the repository. The commit doesn't have to be stored in the
+// repository, which allows linting a commit message before the commit is created.
+func (l *Linter) LintCommit(ctx context.Context, commit *object.Commit) error {
+	if err := l.validateLinting(); err != nil {
+		return err
+	}
+
+	if err := l.CommitLinter.Lint(commit); err != nil {
+		l.ReportFunc(ctx, err)
+
+		if Failed(err) {
+			return &Error{errs: []error{err}}
+		}
+
+		return nil
+	}
+
+	l.PassFunc(ctx, commit)
+
+	if l.Logger.Enabled(ctx, slog.LevelDebug) {
+		l.Logger.LogAttrs(ctx, slog.LevelDebug, "commit passed",
+			slog.Any("commit", commit),
+		)
+	}
+
+	return nil
+}
+
 // Run will traverse the commit tree, calls [Linter.CommitLinter.Lint] for each commit message.
 func (l *Linter) Run(ctx context.Context) error {
 	if err := l.Validate(); err != nil {
@@ -106,12 +152,22 @@ func (l *Linter) Run(ctx context.Context) error {
 	}
 
 	var iter object.CommitIter
-	iter, err = l.Repo.Log(&git.LogOptions{
-		From:  *hash,
-		Order: git.LogOrderBSF,
-	})
-	if err != nil {
-		return fmt.Errorf("could not iterate over commits: %w", err)
+	if len(l.Exclude) > 0 {
+		var commit *object.Commit
+		commit, err = l.Repo.CommitObject(*hash)
+		if err != nil {
+			return fmt.Errorf("bad revision %q: %w", l.Rev, err)
+		}
+
+		iter = object.NewCommitIterBSF(commit, l.Exclude, nil)
+	} else {
+		iter, err = l.Repo.Log(&git.LogOptions{
+			From:  *hash,
+			Order: git.LogOrderBSF,
+		})
+		if err != nil {
+			return fmt.Errorf("could not iterate over commits: %w", err)
+		}
 	}
 	defer iter.Close()
 
@@ -141,13 +197,18 @@ func (l *Linter) Run(ctx context.Context) error {
 
 		err = l.CommitLinter.Lint(commit)
 		if err != nil {
-			accumulatedErrs = append(accumulatedErrs, err)
+			// Warnings are reported but don't fail the linting.
+			if Failed(err) {
+				accumulatedErrs = append(accumulatedErrs, err)
+			}
 
 			l.ReportFunc(ctx, err)
 
 			return nil
 		}
 
+		l.PassFunc(ctx, commit)
+
 		if l.Logger.Enabled(ctx, slog.LevelDebug) {
 			l.Logger.LogAttrs(ctx, slog.LevelDebug, "commit passed",
 				slog.Any("commit", commit),
@@ -211,6 +272,84 @@ func StopAtMergeBases(mergeBases []*object.Commit) StopFunc {
 	}
 }
 
+// ReferencedCommits returns the commits that the references accepted by the filter point at. Tags are peeled.
+func ReferencedCommits(repo *git.Repository, filter func(ref *plumbing.Reference) bool) ([]plumbing.Hash, error) {
+	refs, err := repo.References()
+	if err != nil {
+		return nil, fmt.Errorf("could not get references: %w", err)
+	}
+
+	defer refs.Close()
+
+	var hashes []plumbing.Hash
+
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference || !filter(ref) {
+			return nil
+		}
+
+		hash := ref.Hash()
+
+		if tag, err := repo.TagObject(hash); err == nil {
+			commit, err := tag.Commit()
+			if err != nil {
+				// Tags of anything but commits are not of interest.
+				return nil
+			}
+
+			hash = commit.Hash
+		}
+
+		hashes = append(hashes, hash)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not iterate over references: %w", err)
+	}
+
+	return hashes, nil
+}
+
+// Reachable returns every commit that is reachable from any of the commits. It's intended to be used as
+// [Linter.Exclude], e.g. to not lint commits that have already been pushed.
+func Reachable(repo *git.Repository, hashes ...plumbing.Hash) (map[plumbing.Hash]bool, error) {
+	reachable := make(map[plumbing.Hash]bool)
+
+	if err := AddReachable(repo, reachable, hashes...); err != nil {
+		return nil, err
+	}
+
+	return reachable, nil
+}
+
+// AddReachable adds every commit that is reachable from any of the commits to reachable. The history of commits that
+// are already in reachable isn't walked again, so extending a copy of a set that [Reachable] returned is cheap.
+func AddReachable(repo *git.Repository, reachable map[plumbing.Hash]bool, hashes ...plumbing.Hash) error {
+	for _, hash := range hashes {
+		if reachable[hash] {
+			continue
+		}
+
+		commit, err := repo.CommitObject(hash)
+		if err != nil {
+			return fmt.Errorf("bad commit %s: %w", hash, err)
+		}
+
+		// Commits that have been seen before, and therefore also their parents, are skipped.
+		err = object.NewCommitPreorderIter(commit, reachable, nil).ForEach(func(c *object.Commit) error {
+			reachable[c.Hash] = true
+
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("could not iterate over commits: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // NoStop will cause the linter to never stop. Allows the linter to run until the last commit.
 func NoStop() StopFunc {
 	return func(_ *object.Commit) bool {
@@ -230,22 +369,41 @@ func StopAfterN(n uint) StopFunc {
 // if any commit doesn't adhere to the linter's expectations.
 func NoReporting(_ context.Context, _ error) {}
 
-// SlogReporter will log linter errors using [log/slog].
+// SlogReporter will log linter errors using [log/slog]. Warnings and infos are logged using the levels of the same
+// names, suppressed violations are logged as infos.
 func SlogReporter(logger *slog.Logger) ReportFunc {
 	return func(ctx context.Context, err error) {
-		var lintError LintError
-		if errors.As(err, &lintError) {
-			logger.LogAttrs(ctx, slog.LevelError, "bad commit message",
-				slog.String("hash", lintError.Hash.String()),
+		for _, lintError := range LintErrors(err) {
+			level := slog.LevelError
+
+			switch {
+			case lintError.Suppressed, lintError.Severity == SeverityInfo:
+				level = slog.LevelInfo
+			case lintError.Severity == SeverityWarning:
+				level = slog.LevelWarn
+			}
+
+			attrs := make([]slog.Attr, 0, 6)
+
+			if !lintError.Hash.IsZero() {
+				attrs = append(attrs, slog.String("hash", lintError.Hash.String()))
+			}
+
+			attrs = append(attrs,
+				slog.String("rule", lintError.Rule),
 				slog.Int("pos", lintError.Pos),
-				slog.String("err", errors.Unwrap(err).Error()),
+				slog.String("err", lintError.Err.Error()),
 			)
 
-			return
-		}
+			if lintError.Suppressed {
+				attrs = append(attrs, slog.Bool("suppressed", true))
+			}
 
-		logger.LogAttrs(ctx, slog.LevelError, "bad commit message",
-			slog.String("err", errors.Unwrap(err).Error()),
-		)
+			if lintError.Suggestion != "" {
+				attrs = append(attrs, slog.String("suggestion", lintError.Suggestion))
+			}
+
+			logger.LogAttrs(ctx, level, "bad commit message", attrs...)
+		}
 	}
 }
@@ -11,6 +11,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"maps"
 	"testing"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/go-git/go-git/v5/plumbing/object"
 
 	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitlinter/conventionalcommits"
 	"codeberg.org/somebadcode/commit-tool/internal/repobuilder"
 	"codeberg.org/somebadcode/commit-tool/linter"
 )
@@ -39,6 +41,8 @@ func TestCommitLinter_Lint(t *testing.T) {
 		ReportFunc   linter.ReportFunc
 		StopFunc     linter.StopFunc
 		CommitLinter linter.CommitLinter
+		// ExcludeRev is a revision whose reachable commits are excluded.
+		ExcludeRev plumbing.Revision
 	}
 
 	tests := []struct {
@@ -119,6 +123,71 @@ func TestCommitLinter_Lint(t *testing.T) {
 				OtherRev:     "refs/heads/main",
 			},
 		},
+		{
+			name: "warnings_only",
+			repoOps: []repobuilder.OperationFunc{
+				repobuilder.Commit("feat: Add foo.", commitOpts),
+				repobuilder.Commit("fix: bug #1", commitOpts),
+			},
+			fields: fields{
+				CommitLinter: &commitlinter.Linter{
+					Rules: commitlinter.Rules{
+						commitlinter.NamedRule("subject-case", conventionalcommits.VerifySubjectCase),
+						commitlinter.NamedRule("subject-full-stop", conventionalcommits.VerifySubjectFullStop),
+					},
+					Severities: map[string]linter.Severity{
+						"subject-case":      linter.SeverityWarning,
+						"subject-full-stop": linter.SeverityInfo,
+					},
+				},
+			},
+		},
+		{
+			name: "error_and_warning",
+			repoOps: []repobuilder.OperationFunc{
+				repobuilder.Commit("feat: Add foo.", commitOpts),
+			},
+			fields: fields{
+				CommitLinter: &commitlinter.Linter{
+					Rules: commitlinter.Rules{
+						commitlinter.NamedRule("subject-case", conventionalcommits.VerifySubjectCase),
+						commitlinter.NamedRule("subject-full-stop", conventionalcommits.VerifySubjectFullStop),
+					},
+					Severities: map[string]linter.Severity{
+						"subject-case": linter.SeverityWarning,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Exclude",
+			repoOps: []repobuilder.OperationFunc{
+				repobuilder.Commit("bad commit #1", commitOpts),
+				repobuilder.Commit("bad commit #2", commitOpts),
+				repobuilder.CheckoutBranch("feature/xyz"),
+				repobuilder.Commit("fix: bug #1", commitOpts),
+				repobuilder.Commit("feat: add foo", commitOpts),
+			},
+			fields: fields{
+				CommitLinter: &commitlinter.Linter{},
+				ExcludeRev:   "refs/heads/main",
+			},
+		},
+		{
+			name: "Exclude_bad_commit",
+			repoOps: []repobuilder.OperationFunc{
+				repobuilder.Commit("fix: bug #1", commitOpts),
+				repobuilder.CheckoutBranch("feature/xyz"),
+				repobuilder.Commit("bad commit #1", commitOpts),
+				repobuilder.Commit("feat: add foo", commitOpts),
+			},
+			fields: fields{
+				CommitLinter: &commitlinter.Linter{},
+				ExcludeRev:   "refs/heads/main",
+			},
+			wantErr: true,
+		},
 	}
 
 	t.Parallel()
@@ -145,6 +214,17 @@ func TestCommitLinter_Lint(t *testing.T) {
 				CommitLinter: tt.fields.CommitLinter,
 			}
 
+			if tt.fields.ExcludeRev != "" {
+				hash, err := repo.ResolveRevision(tt.fields.ExcludeRev)
+				if err != nil {
+					t.Fatalf("failed to resolve excluded revision: %v", err)
+				}
+
+				if l.Exclude, err = linter.Reachable(repo, *hash); err != nil {
+					t.Fatalf("Reachable() error = %v", err)
+				}
+			}
+
 			ctx, cancel := context.WithCancel(t.Context())
 			defer cancel()
 
@@ -160,3 +240,51 @@ func TestCommitLinter_Lint(t *testing.T) {
 		})
 	}
 }
+
+func TestAddReachable(t *testing.T) {
+	t.Parallel()
+
+	commitOpts := git.CommitOptions{
+		AllowEmptyCommits: true,
+		Author: &object.Signature{
+			Name:  "Gopher",
+			Email: "gopher@example.com",
+			When:  time.Date(2023, 2, 4, 23, 22, 0, 0, time.UTC),
+		},
+	}
+
+	repo, err := repobuilder.Build(
+		repobuilder.Commit("chore: init", commitOpts),
+		repobuilder.Commit("feat: add foo", commitOpts),
+		repobuilder.CheckoutBranch("feature/xyz"),
+		repobuilder.Commit("feat: add bar", commitOpts),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base, err := repo.ResolveRevision("refs/heads/main")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	feature, err := repo.ResolveRevision("refs/heads/feature/xyz")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reachable, err := linter.Reachable(repo, *base)
+	if err != nil {
+		t.Fatalf("Reachable() error = %v", err)
+	}
+
+	extended := maps.Clone(reachable)
+
+	if err = linter.AddReachable(repo, extended, *feature); err != nil {
+		t.Fatalf("AddReachable() error = %v", err)
+	}
+
+	if len(reachable) != 2 || len(extended) != 3 || !extended[*feature] {
+		t.Errorf("AddReachable() extended %d commits to %d, want 2 to 3 with %s", len(reachable), len(extended), feature)
+	}
+}
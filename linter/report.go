@@ -0,0 +1,314 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package linter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+)
+
+// ShortHashLength is the number of hexadecimal digits of a short commit hash.
+const ShortHashLength = 7
+
+// Report is the result of linting a range of commits.
+type Report struct {
+	Results []Result `json:"results"`
+	Summary Summary  `json:"summary"`
+	// Ranges are the names of the linted ranges of commits in order, including ranges without any commits.
+	Ranges []string `json:"-"`
+}
+
+// Result is the result of linting a single commit.
+type Result struct {
+	// Range names the range of commits that the commit was linted as part of, see [Collector.BeginRange].
+	Range     string   `json:"range,omitempty"`
+	Hash      string   `json:"hash,omitempty"`
+	ShortHash string   `json:"short_hash,omitempty"`
+	Author    Identity `json:"author"`
+	// Subject is the first line of the commit message.
+	Subject    string      `json:"subject"`
+	Type       string      `json:"type,omitempty"`
+	Scope      string      `json:"scope,omitempty"`
+	Violations []Violation `json:"violations"`
+}
+
+// Identity is the name and email address of a person.
+type Identity struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// Violation is a rule that a commit message doesn't adhere to.
+type Violation struct {
+	Rule     string   `json:"rule,omitempty"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	// Offset is the byte offset into the commit message.
+	Offset int `json:"offset"`
+	// Line is the line of the commit message, starting at 1.
+	Line int `json:"line"`
+	// Column is the character (not byte) on the line, starting at 1.
+	Column int `json:"column"`
+	// Suppressed is set if the commit message suppresses the rule.
+	Suppressed bool `json:"suppressed,omitempty"`
+	// Suggestion tells how to fix the violation, if the rule knows.
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// RuleDescriptor describes a rule, for reports that include the rules.
+type RuleDescriptor struct {
+	ID string
+	// Title is a short summary of the rule.
+	Title string
+	// Description explains what the rule checks and why.
+	Description string
+	// Help explains how to write a commit message that adheres to the rule.
+	Help     string
+	Severity Severity
+}
+
+// Summary summarizes the results.
+type Summary struct {
+	Commits  int `json:"commits"`
+	Passed   int `json:"passed"`
+	Failed   int `json:"failed"`
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+	Infos    int `json:"infos"`
+	// Suppressed counts the violations that were suppressed, they are not counted by severity.
+	Suppressed int `json:"suppressed"`
+}
+
+// Failed reports if any of the violations is an error that isn't suppressed.
+func (r Result) Failed() bool {
+	for _, violation := range r.Violations {
+		if violation.Fails() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// label is the severity of the violation for humans, it tells if the violation was suppressed.
+func (v Violation) label() string {
+	if v.Suppressed {
+		return v.Severity.String() + " (suppressed)"
+	}
+
+	return v.Severity.String()
+}
+
+// text is the message of the violation followed by the suggestion, for reports that only have room for a message.
+func (v Violation) text() string {
+	if v.Suggestion == "" {
+		return v.Message
+	}
+
+	return v.Message + "; " + v.Suggestion
+}
+
+// Fails reports if the violation makes linting fail, which only errors that aren't suppressed do.
+func (v Violation) Fails() bool {
+	return v.Severity == SeverityError && !v.Suppressed
+}
+
+// NewResult creates the result of linting a commit. The error is what [CommitLinter] returned, it's nil if the commit
+// passed. The commit may be nil if the error is a [LintError] that knows the commit.
+func NewResult(commit *object.Commit, err error) Result {
+	result := Result{
+		Violations: []Violation{},
+	}
+
+	lintErrors := LintErrors(err)
+
+	if commit == nil {
+		for _, lintError := range lintErrors {
+			if lintError.Commit != nil {
+				commit = lintError.Commit
+
+				break
+			}
+		}
+	}
+
+	var message string
+
+	if commit != nil {
+		message = commit.Message
+
+		// A commit that hasn't been created yet doesn't have a hash.
+		if !commit.Hash.IsZero() {
+			result.Hash = commit.Hash.String()
+			result.ShortHash = result.Hash[:ShortHashLength]
+		}
+
+		result.Author = Identity{
+			Name:  commit.Author.Name,
+			Email: commit.Author.Email,
+		}
+		result.Subject, _, _ = strings.Cut(message, "\n")
+
+		// The parsed message is incomplete if parsing failed, which is fine since only the header is of interest.
+		parsed, _ := commitparser.Parse(message)
+		result.Type = parsed.Type
+		result.Scope = parsed.Scope
+	} else if len(lintErrors) > 0 && !lintErrors[0].Hash.IsZero() {
+		result.Hash = lintErrors[0].Hash.String()
+		result.ShortHash = result.Hash[:ShortHashLength]
+	}
+
+	for _, lintError := range lintErrors {
+		line, column := position(message, lintError.Pos)
+
+		result.Violations = append(result.Violations, Violation{
+			Rule:       lintError.Rule,
+			Severity:   lintError.Severity,
+			Message:    lintError.Err.Error(),
+			Offset:     lintError.Pos,
+			Line:       line,
+			Column:     column,
+			Suppressed: lintError.Suppressed,
+			Suggestion: lintError.Suggestion,
+		})
+	}
+
+	return result
+}
+
+// LintErrors returns every lint error in the error tree, e.g. of the error that [CommitLinter] returned. Errors that
+// are not lint errors are treated as lint errors of unknown rules.
+func LintErrors(err error) []LintError {
+	if err == nil {
+		return nil
+	}
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var lintErrors []LintError
+
+		for _, err = range joined.Unwrap() {
+			lintErrors = append(lintErrors, LintErrors(err)...)
+		}
+
+		return lintErrors
+	}
+
+	var lintError LintError
+	if errors.As(err, &lintError) {
+		return []LintError{lintError}
+	}
+
+	return []LintError{{Err: err}}
+}
+
+// Failed reports if any of the lint errors in the error tree is an error, rather than a warning or info, that isn't
+// suppressed.
+func Failed(err error) bool {
+	for _, lintError := range LintErrors(err) {
+		if lintError.Fails() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// position returns the line and column of the byte offset into the message.
+func position(message string, offset int) (line int, column int) {
+	offset = min(max(offset, 0), len(message))
+	before := message[:offset]
+
+	line = strings.Count(before, "\n") + 1
+	column = utf8.RuneCountInString(before[strings.LastIndexByte(before, '\n')+1:]) + 1
+
+	return line, column
+}
+
+// Collector collects the result of every linted commit, so that a report can be written once the traversal is done.
+// Use [Collector.Report] as [Linter.ReportFunc] and [Collector.Pass] as [Linter.PassFunc].
+type Collector struct {
+	results []Result
+	ranges  []string
+	current string
+}
+
+// BeginRange names the range of commits that the following results belong to.
+func (c *Collector) BeginRange(name string) {
+	c.current = name
+	c.ranges = append(c.ranges, name)
+}
+
+func (c *Collector) Report(_ context.Context, err error) {
+	c.add(NewResult(nil, err))
+}
+
+func (c *Collector) Pass(_ context.Context, commit *object.Commit) {
+	c.add(NewResult(commit, nil))
+}
+
+func (c *Collector) add(result Result) {
+	result.Range = c.current
+	c.results = append(c.results, result)
+}
+
+// Finish returns the collected results with a summary.
+func (c *Collector) Finish() Report {
+	report := Report{
+		Results: c.results,
+		Ranges:  c.ranges,
+	}
+
+	if report.Results == nil {
+		report.Results = []Result{}
+	}
+
+	for _, result := range report.Results {
+		report.Summary.Commits++
+
+		if result.Failed() {
+			report.Summary.Failed++
+		} else {
+			report.Summary.Passed++
+		}
+
+		for _, violation := range result.Violations {
+			if violation.Suppressed {
+				report.Summary.Suppressed++
+
+				continue
+			}
+
+			switch violation.Severity {
+			case SeverityError:
+				report.Summary.Errors++
+			case SeverityWarning:
+				report.Summary.Warnings++
+			case SeverityInfo:
+				report.Summary.Infos++
+			}
+		}
+	}
+
+	return report
+}
+
+// WriteJSON writes the report as an indented JSON document.
+func WriteJSON(w io.Writer, report Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(report)
+}
@@ -0,0 +1,125 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package linter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/internal/repobuilder"
+	"codeberg.org/somebadcode/commit-tool/linter"
+)
+
+func TestCollector(t *testing.T) {
+	t.Parallel()
+
+	commitOpts := git.CommitOptions{
+		AllowEmptyCommits: true,
+		Author: &object.Signature{
+			Name:  "Gopher",
+			Email: "gopher@example.com",
+			When:  time.Date(2023, 2, 4, 23, 22, 0, 0, time.UTC),
+		},
+	}
+
+	repo, err := repobuilder.Build(
+		repobuilder.Commit("feat(foo): add foo", commitOpts),
+		repobuilder.Commit("fix: bug #1\n\nBody", commitOpts),
+		repobuilder.Commit("añadir foo", commitOpts),
+	)
+	if err != nil {
+		t.Fatalf("failed to build repo: %v", err)
+	}
+
+	var collector linter.Collector
+
+	l := &linter.Linter{
+		Repo:         repo,
+		ReportFunc:   collector.Report,
+		PassFunc:     collector.Pass,
+		CommitLinter: &commitlinter.Linter{},
+	}
+
+	if err = l.Run(t.Context()); err == nil {
+		t.Fatal("Run() error = nil, want violations")
+	}
+
+	author := linter.Identity{Name: "Gopher", Email: "gopher@example.com"}
+
+	want := linter.Report{
+		Results: []linter.Result{
+			{
+				Author:  author,
+				Subject: "añadir foo",
+				Violations: []linter.Violation{
+					{
+						Rule:     commitlinter.RuleHeaderFormat,
+						Severity: linter.SeverityError,
+						Message:  "unexpected character at 11: invalid commit type",
+						Offset:   11,
+						Line:     1,
+						Column:   11,
+					},
+				},
+			},
+			{
+				Author:     author,
+				Subject:    "fix: bug #1",
+				Type:       "fix",
+				Violations: []linter.Violation{},
+			},
+			{
+				Author:     author,
+				Subject:    "feat(foo): add foo",
+				Type:       "feat",
+				Scope:      "foo",
+				Violations: []linter.Violation{},
+			},
+		},
+		Summary: linter.Summary{
+			Commits: 3,
+			Passed:  2,
+			Failed:  1,
+			Errors:  1,
+		},
+	}
+
+	got := collector.Finish()
+
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(linter.Result{}, "Hash", "ShortHash")); diff != "" {
+		t.Errorf("Finish() mismatch (-want +got):\n%s", diff)
+	}
+
+	for _, result := range got.Results {
+		if len(result.Hash) != 40 || result.ShortHash != result.Hash[:linter.ShortHashLength] {
+			t.Errorf("bad hashes %q and %q", result.Hash, result.ShortHash)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err = linter.WriteJSON(&buf, got); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+
+	var decoded linter.Report
+	if err = json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("failed to decode report: %v", err)
+	}
+
+	if diff := cmp.Diff(got, decoded); diff != "" {
+		t.Errorf("decoded report mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package linter
+
+import (
+	"context"
+	"io"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Reporter reports the result of each linted commit and finishes the report once linting is done. Use
+// [Reporter.Report] as [Linter.ReportFunc] and [Reporter.Pass] as [Linter.PassFunc].
+type Reporter interface {
+	// BeginRange names the range of commits that are about to be linted.
+	BeginRange(name string)
+	Report(ctx context.Context, err error)
+	Pass(ctx context.Context, commit *object.Commit)
+	// Finish is called once, after every range of commits has been linted.
+	Finish() error
+}
+
+// collectingReporter writes the collected results once linting is done.
+type collectingReporter struct {
+	collector Collector
+	write     func(report Report) error
+}
+
+func (r *collectingReporter) BeginRange(name string) {
+	r.collector.BeginRange(name)
+}
+
+func (r *collectingReporter) Report(ctx context.Context, err error) {
+	r.collector.Report(ctx, err)
+}
+
+func (r *collectingReporter) Pass(ctx context.Context, commit *object.Commit) {
+	r.collector.Pass(ctx, commit)
+}
+
+func (r *collectingReporter) Finish() error {
+	return r.write(r.collector.Finish())
+}
+
+// NewJSONReporter returns a reporter that writes a JSON report, see [WriteJSON].
+func NewJSONReporter(w io.Writer) Reporter {
+	return &collectingReporter{
+		write: func(report Report) error {
+			return WriteJSON(w, report)
+		},
+	}
+}
+
+// NewSARIFReporter returns a reporter that writes a SARIF log, see [WriteSARIF].
+func NewSARIFReporter(w io.Writer, rules []RuleDescriptor) Reporter {
+	return &collectingReporter{
+		write: func(report Report) error {
+			return WriteSARIF(w, report, rules)
+		},
+	}
+}
+
+// NewJUnitReporter returns a reporter that writes a JUnit XML report, see [WriteJUnit].
+func NewJUnitReporter(w io.Writer) Reporter {
+	return &collectingReporter{
+		write: func(report Report) error {
+			return WriteJUnit(w, report)
+		},
+	}
+}
@@ -0,0 +1,184 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package linter_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/google/go-cmp/cmp"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitlinter/conventionalcommits"
+	"codeberg.org/somebadcode/commit-tool/internal/repobuilder"
+	"codeberg.org/somebadcode/commit-tool/linter"
+)
+
+func TestReporters(t *testing.T) {
+	t.Parallel()
+
+	commitOpts := git.CommitOptions{
+		AllowEmptyCommits: true,
+		Author: &object.Signature{
+			Name:  "Gopher",
+			Email: "gopher@example.com",
+			When:  time.Date(2023, 2, 4, 23, 22, 0, 0, time.UTC),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		newReporter func(w io.Writer) linter.Reporter
+	}{
+		{
+			name: "junit.xml",
+			newReporter: func(w io.Writer) linter.Reporter {
+				return linter.NewJUnitReporter(w)
+			},
+		},
+		{
+			name: "report.txt",
+			newReporter: func(w io.Writer) linter.Reporter {
+				return linter.NewTextReporter(w, []linter.RuleDescriptor{
+					{
+						ID:   "subject-case",
+						Help: "Start the subject with a lower case letter.",
+					},
+				}, false)
+			},
+		},
+		{
+			name: "report.tap",
+			newReporter: func(w io.Writer) linter.Reporter {
+				return linter.NewTAPReporter(w)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo, err := repobuilder.Build(
+				repobuilder.Commit("feat(foo): add foo", commitOpts),
+				repobuilder.WriteFile("docs/bugs.md", "Bug #1\n"),
+				repobuilder.Commit("fix: Bug #1", commitOpts),
+				repobuilder.Commit("fix: Vendored upstream fix\n\nLint-Ignore: subject-case", commitOpts),
+				repobuilder.CheckoutBranch("feature/bar"),
+				repobuilder.Commit("added bar <b>\n\nSome body.", commitOpts),
+				repobuilder.Commit("docs: explain bar", commitOpts),
+			)
+			if err != nil {
+				t.Fatalf("failed to build repo: %v", err)
+			}
+
+			var buf bytes.Buffer
+
+			reporter := tt.newReporter(&buf)
+
+			// Lint the main branch and the feature branch as separate ranges.
+			for _, rev := range []string{"main", "feature/bar"} {
+				l := &linter.Linter{
+					Repo:       repo,
+					Rev:        plumbing.Revision("refs/heads/" + rev),
+					ReportFunc: reporter.Report,
+					PassFunc:   reporter.Pass,
+					CommitLinter: &commitlinter.Linter{
+						Rules: commitlinter.Rules{
+							commitlinter.NamedRule("subject-case", conventionalcommits.VerifySubjectCase),
+							commitlinter.NamedRule("type-path", conventionalcommits.TypePath(map[string][]string{
+								"docs": {"docs/**", "*.md"},
+							}, nil, repo)),
+						},
+						Severities: map[string]linter.Severity{
+							"type-path": linter.SeverityWarning,
+						},
+						Suppressible: []string{commitlinter.AnyRule},
+					},
+				}
+
+				if rev != "main" {
+					l.OtherRev = "refs/heads/main"
+				}
+
+				reporter.BeginRange(rev)
+
+				_ = l.Run(t.Context())
+			}
+
+			if err = reporter.Finish(); err != nil {
+				t.Fatalf("Finish() error = %v", err)
+			}
+
+			golden := filepath.Join("testdata", "reporters", tt.name)
+
+			if *update {
+				if err = os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(string(want), buf.String()); diff != "" {
+				t.Errorf("report mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	t.Parallel()
+
+	report := linter.Report{
+		Ranges: []string{"main", "feature/empty"},
+		Results: []linter.Result{
+			{
+				Range:     "main",
+				ShortHash: "c96b376",
+				Subject:   "feat(foo): add foo",
+				Violations: []linter.Violation{
+					{Severity: linter.SeverityError, Message: "failed to diff commit", Line: 1, Column: 1},
+					{Rule: "subject-case", Severity: linter.SeverityError, Message: "bad subject", Line: 1, Column: 6},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+
+	if err := linter.WriteJUnit(&buf, report); err != nil {
+		t.Fatalf("WriteJUnit() error = %v", err)
+	}
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="commit-tool" tests="1" failures="0" errors="1">
+  <testsuite name="main" tests="1" failures="0" errors="1">
+    <testcase name="c96b376 feat(foo): add foo" classname="main">
+      <failure message="bad subject" type="subject-case">line 1, column 6: bad subject</failure>
+      <error message="failed to diff commit">line 1, column 1: failed to diff commit</error>
+    </testcase>
+  </testsuite>
+  <testsuite name="feature/empty" tests="0" failures="0" errors="0"></testsuite>
+</testsuites>
+`
+
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("WriteJUnit() mismatch (-want +got):\n%s", diff)
+	}
+}
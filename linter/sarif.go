@@ -0,0 +1,214 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package linter
+
+import (
+	"encoding/json"
+	"io"
+	"runtime/debug"
+	"slices"
+
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+)
+
+const (
+	sarifVersion   = "2.1.0"
+	sarifSchema    = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName       = "commit-tool"
+	toolInfoURI    = "https://codeberg.org/somebadcode/commit-tool"
+	commitLocation = "commit"
+)
+
+// The SARIF types only have the properties that are used, see https://docs.oasis-open.org/sarif/sarif/v2.1.0/.
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifToolComponent `json:"driver"`
+}
+
+type sarifToolComponent struct {
+	Name           string                     `json:"name"`
+	Version        string                     `json:"version,omitempty"`
+	InformationURI string                     `json:"informationUri"`
+	Rules          []sarifReportingDescriptor `json:"rules"`
+}
+
+type sarifReportingDescriptor struct {
+	ID                   string                      `json:"id"`
+	ShortDescription     *sarifMessage               `json:"shortDescription,omitempty"`
+	FullDescription      *sarifMessage               `json:"fullDescription,omitempty"`
+	Help                 *sarifMessage               `json:"help,omitempty"`
+	DefaultConfiguration sarifReportingConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifReportingConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId,omitempty"`
+	RuleIndex *int            `json:"ruleIndex,omitempty"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+	// Suppressions is only set if the result was suppressed.
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+	Properties   sarifProperties    `json:"properties"`
+}
+
+// sarifSuppression tells that a result was suppressed in the commit message, which is the source of the result.
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// sarifProperties is the property bag of a result, it tells where in the commit message the violation is.
+type sarifProperties struct {
+	Subject string `json:"subject"`
+	Offset  int    `json:"offset"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+}
+
+// WriteSARIF writes the report as a SARIF 2.1.0 log. Each violation is a result whose logical location is the commit.
+// The rules are the rules that the results may refer to, rules that are not described are referred to by ID only.
+func WriteSARIF(w io.Writer, report Report, rules []RuleDescriptor) error {
+	driver := sarifToolComponent{
+		Name:           toolName,
+		Version:        toolVersion(),
+		InformationURI: toolInfoURI,
+		Rules:          make([]sarifReportingDescriptor, 0, len(rules)),
+	}
+
+	for _, rule := range rules {
+		descriptor := sarifReportingDescriptor{
+			ID: rule.ID,
+			DefaultConfiguration: sarifReportingConfiguration{
+				Level: sarifLevel(rule.Severity),
+			},
+		}
+
+		if rule.Title != "" {
+			descriptor.ShortDescription = &sarifMessage{Text: rule.Title}
+		}
+
+		if rule.Description != "" {
+			descriptor.FullDescription = &sarifMessage{Text: rule.Description}
+		}
+
+		if rule.Help != "" {
+			descriptor.Help = &sarifMessage{Text: rule.Help}
+		}
+
+		driver.Rules = append(driver.Rules, descriptor)
+	}
+
+	run := sarifRun{
+		Tool:    sarifTool{Driver: driver},
+		Results: []sarifResult{},
+	}
+
+	for _, result := range report.Results {
+		for _, violation := range result.Violations {
+			r := sarifResult{
+				RuleID:  violation.Rule,
+				Level:   sarifLevel(violation.Severity),
+				Message: sarifMessage{Text: violation.text()},
+				Properties: sarifProperties{
+					Subject: result.Subject,
+					Offset:  violation.Offset,
+					Line:    violation.Line,
+					Column:  violation.Column,
+				},
+			}
+
+			if i := slices.IndexFunc(rules, func(rule RuleDescriptor) bool { return rule.ID == violation.Rule }); i >= 0 {
+				r.RuleIndex = &i
+			}
+
+			if violation.Suppressed {
+				r.Suppressions = []sarifSuppression{
+					{
+						Kind:          "inSource",
+						Justification: "suppressed by a " + commitparser.TrailerKeyLintIgnore + " trailer",
+					},
+				}
+			}
+
+			if result.Hash != "" {
+				r.Locations = []sarifLocation{
+					{
+						LogicalLocations: []sarifLogicalLocation{
+							{
+								Name:               result.ShortHash,
+								FullyQualifiedName: result.Hash,
+								Kind:               commitLocation,
+							},
+						},
+					},
+				}
+			}
+
+			run.Results = append(run.Results, r)
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	})
+}
+
+func sarifLevel(severity Severity) string {
+	switch severity {
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "note"
+	}
+
+	return "error"
+}
+
+// toolVersion returns the version of the main module, if it's known.
+func toolVersion() string {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok || bi.Main.Version == "(devel)" {
+		return ""
+	}
+
+	return bi.Main.Version
+}
@@ -0,0 +1,189 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package linter_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/google/go-cmp/cmp"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+
+	"codeberg.org/somebadcode/commit-tool/commitlinter"
+	"codeberg.org/somebadcode/commit-tool/commitlinter/conventionalcommits"
+	"codeberg.org/somebadcode/commit-tool/internal/repobuilder"
+	"codeberg.org/somebadcode/commit-tool/linter"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// sarifSchemaURL is the ID of the SARIF 2.1.0 schema. A copy of it is in testdata, so logs are validated offline.
+const sarifSchemaURL = "https://docs.oasis-open.org/sarif/sarif/v2.1.0/errata01/os/schemas/sarif-schema-2.1.0.json"
+
+// sarifSchema compiles the SARIF 2.1.0 schema.
+var sarifSchema = sync.OnceValues(func() (*jsonschema.Schema, error) {
+	f, err := os.Open(filepath.Join("testdata", "sarif", "sarif-schema-2.1.0.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	doc, err := jsonschema.UnmarshalJSON(f)
+	if err != nil {
+		return nil, err
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.AssertFormat()
+
+	if err = compiler.AddResource(sarifSchemaURL, doc); err != nil {
+		return nil, err
+	}
+
+	return compiler.Compile(sarifSchemaURL)
+})
+
+// validateSARIF validates a SARIF log against the SARIF 2.1.0 schema.
+func validateSARIF(t *testing.T, b []byte) error {
+	t.Helper()
+
+	schema, err := sarifSchema()
+	if err != nil {
+		t.Fatalf("failed to compile SARIF schema: %v", err)
+	}
+
+	log, err := jsonschema.UnmarshalJSON(bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+
+	return schema.Validate(log)
+}
+
+func TestWriteSARIF(t *testing.T) {
+	t.Parallel()
+
+	commitOpts := git.CommitOptions{
+		AllowEmptyCommits: true,
+		Author: &object.Signature{
+			Name:  "Gopher",
+			Email: "gopher@example.com",
+			When:  time.Date(2023, 2, 4, 23, 22, 0, 0, time.UTC),
+		},
+	}
+
+	rules := []linter.RuleDescriptor{
+		{
+			ID:          commitlinter.RuleHeaderFormat,
+			Title:       "Conventional commit header",
+			Description: "The header must be a conventional commit header.",
+			Help:        "Start the commit message with a header like \"type(scope): subject\".",
+		},
+		{
+			ID:    "subject-case",
+			Title: "Lower case subject",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		repoOps []repobuilder.OperationFunc
+	}{
+		{
+			name: "violations",
+			repoOps: []repobuilder.OperationFunc{
+				repobuilder.Commit("feat(foo): add foo", commitOpts),
+				repobuilder.Commit("fix: Bug #1", commitOpts),
+				repobuilder.Commit("added bar\n\nSome body.", commitOpts),
+				repobuilder.Commit("docs: Explain bar.", commitOpts),
+			},
+		},
+		{
+			name: "no_violations",
+			repoOps: []repobuilder.OperationFunc{
+				repobuilder.Commit("feat(foo): add foo", commitOpts),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo, err := repobuilder.Build(tt.repoOps...)
+			if err != nil {
+				t.Fatalf("failed to build repo: %v", err)
+			}
+
+			var collector linter.Collector
+
+			l := &linter.Linter{
+				Repo:       repo,
+				ReportFunc: collector.Report,
+				PassFunc:   collector.Pass,
+				CommitLinter: &commitlinter.Linter{
+					Rules: commitlinter.Rules{
+						commitlinter.NamedRule("subject-case", conventionalcommits.VerifySubjectCase),
+						// Rules that aren't described are referred to by ID only.
+						commitlinter.NamedRule("subject-full-stop", conventionalcommits.VerifySubjectFullStop),
+					},
+					Severities: map[string]linter.Severity{
+						"subject-full-stop": linter.SeverityWarning,
+					},
+				},
+			}
+
+			_ = l.Run(t.Context())
+
+			var buf bytes.Buffer
+			if err = linter.WriteSARIF(&buf, collector.Finish(), rules); err != nil {
+				t.Fatalf("WriteSARIF() error = %v", err)
+			}
+
+			golden := filepath.Join("testdata", "sarif", tt.name+".sarif")
+
+			if *update {
+				if err = os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(string(want), buf.String()); diff != "" {
+				t.Errorf("WriteSARIF() mismatch (-want +got):\n%s", diff)
+			}
+
+			if err = validateSARIF(t, want); err != nil {
+				t.Errorf("golden file %s isn't a valid SARIF 2.1.0 log: %v", golden, err)
+			}
+		})
+	}
+}
+
+func TestWriteSARIF_schema(t *testing.T) {
+	t.Parallel()
+
+	// The schema must reject logs that aren't SARIF 2.1.0, or the validation of the golden files proves nothing.
+	if err := validateSARIF(t, []byte(`{"version": "2.0.0", "runs": [{"results": []}]}`)); err == nil {
+		t.Error("validateSARIF() accepted an invalid log")
+	}
+}
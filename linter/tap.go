@@ -0,0 +1,135 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package linter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"gopkg.in/yaml.v3"
+)
+
+// TAPReporter writes the Test Anything Protocol (version 14) as commits are linted. Each commit is a test point and its
+// violations are written as a YAML diagnostic block. The plan is written when linting is done.
+type TAPReporter struct {
+	w       io.Writer
+	started bool
+	tests   int
+	err     error
+}
+
+type tapViolation struct {
+	Rule     string `yaml:"rule,omitempty"`
+	Severity string `yaml:"severity"`
+	Message  string `yaml:"message"`
+	Line     int    `yaml:"line"`
+	Column   int    `yaml:"column"`
+	// Suppressed is set if the commit message suppresses the rule.
+	Suppressed bool `yaml:"suppressed,omitempty"`
+	// Suggestion tells how to fix the violation, if the rule knows.
+	Suggestion string `yaml:"suggestion,omitempty"`
+}
+
+var _ Reporter = (*TAPReporter)(nil)
+
+func NewTAPReporter(w io.Writer) *TAPReporter {
+	return &TAPReporter{
+		w: w,
+	}
+}
+
+func (r *TAPReporter) BeginRange(name string) {
+	r.start()
+	r.printf("# %s\n", name)
+}
+
+func (r *TAPReporter) Report(_ context.Context, err error) {
+	r.write(NewResult(nil, err))
+}
+
+func (r *TAPReporter) Pass(_ context.Context, commit *object.Commit) {
+	r.write(NewResult(commit, nil))
+}
+
+// Finish writes the plan and returns the first error that occurred while writing.
+func (r *TAPReporter) Finish() error {
+	r.start()
+	r.printf("1..%d\n", r.tests)
+
+	return r.err
+}
+
+func (r *TAPReporter) write(result Result) {
+	r.start()
+	r.tests++
+
+	status := "ok"
+	if result.Failed() {
+		status = "not ok"
+	}
+
+	// A hash character starts a directive, so it must be escaped in the description.
+	description := strings.ReplaceAll(strings.TrimSpace(result.ShortHash+" "+result.Subject), "#", `\#`)
+
+	r.printf("%s %d - %s\n", status, r.tests, description)
+
+	if len(result.Violations) == 0 {
+		return
+	}
+
+	violations := make([]tapViolation, 0, len(result.Violations))
+	for _, violation := range result.Violations {
+		violations = append(violations, tapViolation{
+			Rule:       violation.Rule,
+			Severity:   violation.Severity.String(),
+			Message:    violation.Message,
+			Line:       violation.Line,
+			Column:     violation.Column,
+			Suppressed: violation.Suppressed,
+			Suggestion: violation.Suggestion,
+		})
+	}
+
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(map[string]any{"violations": violations}); err != nil {
+		r.err = err
+
+		return
+	}
+
+	r.printf("  ---\n")
+
+	for line := range strings.Lines(buf.String()) {
+		r.printf("  %s", line)
+	}
+
+	r.printf("  ...\n")
+}
+
+func (r *TAPReporter) start() {
+	if !r.started {
+		r.started = true
+		r.printf("TAP version 14\n")
+	}
+}
+
+func (r *TAPReporter) printf(format string, args ...any) {
+	if r.err != nil {
+		return
+	}
+
+	_, r.err = fmt.Fprintf(r.w, format, args...)
+}
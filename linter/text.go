@@ -0,0 +1,203 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package linter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/internal/textwidth"
+)
+
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiDim    = "\x1b[2m"
+	ansiRed    = "\x1b[1;31m"
+	ansiYellow = "\x1b[1;33m"
+	ansiCyan   = "\x1b[1;36m"
+	ansiBlue   = "\x1b[1;34m"
+)
+
+// TextReporter writes compiler-style diagnostics for humans. Each violation shows the commit, the line of the commit
+// message with a caret under where the violation is, and the rule with a hint of how to fix it.
+type TextReporter struct {
+	w     io.Writer
+	rules []RuleDescriptor
+	color bool
+
+	collector Collector
+	err       error
+}
+
+var _ Reporter = (*TextReporter)(nil)
+
+// NewTextReporter returns a reporter that writes to w. The rules are used for hints. Color should only be enabled if w
+// is a terminal.
+func NewTextReporter(w io.Writer, rules []RuleDescriptor, color bool) *TextReporter {
+	return &TextReporter{
+		w:     w,
+		rules: rules,
+		color: color,
+	}
+}
+
+func (r *TextReporter) BeginRange(name string) {
+	r.collector.BeginRange(name)
+}
+
+func (r *TextReporter) Report(_ context.Context, err error) {
+	result := NewResult(nil, err)
+	r.collector.add(result)
+
+	var message string
+
+	// The commit is needed to show the offending lines.
+	for _, lintError := range LintErrors(err) {
+		if lintError.Commit != nil {
+			message = lintError.Commit.Message
+
+			break
+		}
+	}
+
+	r.write(result, message)
+}
+
+func (r *TextReporter) Pass(ctx context.Context, commit *object.Commit) {
+	r.collector.Pass(ctx, commit)
+}
+
+// Finish writes a summary and returns the first error that occurred while writing.
+func (r *TextReporter) Finish() error {
+	summary := r.collector.Finish().Summary
+
+	counts := pluralize(summary.Errors, "error") + ", " + pluralize(summary.Warnings, "warning")
+	if summary.Suppressed > 0 {
+		counts += fmt.Sprintf(", %d suppressed", summary.Suppressed)
+	}
+
+	r.printf("%s: %d passed, %d failed (%s)\n",
+		r.paint(ansiBold, pluralize(summary.Commits, "commit")),
+		summary.Passed,
+		summary.Failed,
+		counts,
+	)
+
+	return r.err
+}
+
+func (r *TextReporter) write(result Result, message string) {
+	if result.ShortHash != "" {
+		r.printf("%s ", r.paint(ansiBold, result.ShortHash))
+	}
+
+	r.printf("%s\n", result.Subject)
+
+	lines := strings.Split(message, "\n")
+
+	for _, violation := range result.Violations {
+		var color string
+
+		switch {
+		case violation.Suppressed:
+			color = ansiDim
+		case violation.Severity == SeverityError:
+			color = ansiRed
+		case violation.Severity == SeverityWarning:
+			color = ansiYellow
+		default:
+			color = ansiCyan
+		}
+
+		r.printf("%s: %s\n", r.paint(color, violation.label()), violation.Message)
+
+		if violation.Line <= len(lines) && message != "" {
+			line := lines[violation.Line-1]
+			number := strconv.Itoa(violation.Line)
+			gutter := strings.Repeat(" ", len(number))
+
+			r.printf("  %s %s\n", r.paint(ansiBlue, number+" |"), line)
+			r.printf("  %s %s%s\n", r.paint(ansiBlue, gutter+" |"), indentation(line, violation.Column), r.paint(color, "^"))
+		}
+
+		hint := violation.Rule
+
+		for _, rule := range r.rules {
+			if rule.ID == violation.Rule && rule.Help != "" {
+				hint += ": " + rule.Help
+
+				break
+			}
+		}
+
+		if hint != "" {
+			r.printf("  %s %s\n", r.paint(ansiBlue, "="), hint)
+		}
+
+		if violation.Suggestion != "" {
+			r.printf("  %s suggestion: %s\n", r.paint(ansiBlue, "="), violation.Suggestion)
+		}
+	}
+
+	r.printf("\n")
+}
+
+// indentation returns the whitespace that puts a caret under the column of the line. Tabs are kept so that the caret
+// lines up with the line no matter how wide tabs are, and wide characters are indented by two spaces.
+func indentation(line string, column int) string {
+	var sb strings.Builder
+
+	for i, char := range []rune(line) {
+		if i >= column-1 {
+			break
+		}
+
+		if char == '\t' {
+			sb.WriteRune('\t')
+		} else {
+			sb.WriteString(strings.Repeat(" ", textwidth.RuneWidth(char)))
+		}
+	}
+
+	// The column may be past the end of the line.
+	if n := column - 1 - len([]rune(line)); n > 0 {
+		sb.WriteString(strings.Repeat(" ", n))
+	}
+
+	return sb.String()
+}
+
+func (r *TextReporter) paint(color string, s string) string {
+	if !r.color {
+		return s
+	}
+
+	return color + s + ansiReset
+}
+
+func (r *TextReporter) printf(format string, args ...any) {
+	if r.err != nil {
+		return
+	}
+
+	_, r.err = fmt.Fprintf(r.w, format, args...)
+}
+
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,201 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+// Package mailmap maps the names and email addresses of commits to canonical ones, the same way git does using a
+// .mailmap file, see gitmailmap(5).
+package mailmap
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+
+	"codeberg.org/somebadcode/commit-tool/internal/repofile"
+)
+
+// FileName is the name of the mailmap file at the root of a repository.
+const FileName = ".mailmap"
+
+// Mailmap maps identities to canonical ones. The zero value maps every identity to itself.
+type Mailmap struct {
+	// entries are the canonical identities by the lower case email address of commits.
+	entries map[string]*entry
+	// proper are the lower case canonical email addresses.
+	proper map[string]struct{}
+}
+
+type entry struct {
+	name  string
+	email string
+	// names are canonical identities by the lower case name of commits, for lines that also match the name.
+	names map[string]identity
+}
+
+type identity struct {
+	name  string
+	email string
+}
+
+// Parse parses a mailmap file. Each line maps an email address, optionally with a name, to a canonical name, a
+// canonical email address or both:
+//
+//	Proper Name <commit@email>
+//	<proper@email> <commit@email>
+//	Proper Name <proper@email> <commit@email>
+//	Proper Name <proper@email> Commit Name <commit@email>
+func Parse(r io.Reader) (*Mailmap, error) {
+	m := &Mailmap{
+		entries: make(map[string]*entry),
+		proper:  make(map[string]struct{}),
+	}
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+
+		properName, properEmail, rest, ok := parseIdentity(line)
+		if !ok {
+			continue
+		}
+
+		commitName, commitEmail, _, ok := parseIdentity(rest)
+		if !ok {
+			// The only email address is both the proper and the commit email address.
+			commitEmail = properEmail
+			properEmail = ""
+		}
+
+		m.add(properName, properEmail, commitName, commitEmail)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading mailmap: %w", err)
+	}
+
+	return m, nil
+}
+
+// Load loads the mailmap of the repository, from the worktree or from HEAD of a bare repository. A repository without
+// a mailmap has an empty mailmap.
+func Load(repo *git.Repository) (*Mailmap, error) {
+	f, err := repofile.Open(repo, FileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Mailmap{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return Parse(f)
+}
+
+// Map returns the canonical name and email address of the identity. A line of the mailmap that matches both the name
+// and the email address takes precedence over a line that only matches the email address. Both are matched case
+// insensitively.
+func (m *Mailmap) Map(name string, email string) (string, string) {
+	if m == nil || m.entries == nil {
+		return name, email
+	}
+
+	e, found := m.entries[strings.ToLower(email)]
+	if !found {
+		return name, email
+	}
+
+	proper := identity{name: e.name, email: e.email}
+	if byName, found := e.names[strings.ToLower(name)]; found {
+		proper = byName
+	}
+
+	if proper.name != "" {
+		name = proper.name
+	}
+
+	if proper.email != "" {
+		email = proper.email
+	}
+
+	return name, email
+}
+
+// Knows reports if the mailmap has the email address, as the email address of commits or as a canonical one. It's
+// matched case insensitively.
+func (m *Mailmap) Knows(email string) bool {
+	if m == nil {
+		return false
+	}
+
+	email = strings.ToLower(email)
+
+	if _, found := m.entries[email]; found {
+		return true
+	}
+
+	_, found := m.proper[email]
+
+	return found
+}
+
+func (m *Mailmap) add(properName, properEmail, commitName, commitEmail string) {
+	if properEmail != "" {
+		m.proper[strings.ToLower(properEmail)] = struct{}{}
+	}
+
+	key := strings.ToLower(commitEmail)
+
+	e, found := m.entries[key]
+	if !found {
+		e = &entry{}
+		m.entries[key] = e
+	}
+
+	if commitName == "" {
+		// Later lines add to or replace what earlier lines map the email address to.
+		if properName != "" {
+			e.name = properName
+		}
+
+		if properEmail != "" {
+			e.email = properEmail
+		}
+
+		return
+	}
+
+	if e.names == nil {
+		e.names = make(map[string]identity)
+	}
+
+	e.names[strings.ToLower(commitName)] = identity{name: properName, email: properEmail}
+}
+
+// parseIdentity parses an optional name followed by an email address in angle brackets, and returns the rest of the
+// line.
+func parseIdentity(s string) (name string, email string, rest string, ok bool) {
+	start := strings.IndexByte(s, '<')
+	if start == -1 {
+		return "", "", s, false
+	}
+
+	end := strings.IndexByte(s[start:], '>')
+	if end == -1 {
+		return "", "", s, false
+	}
+
+	end += start
+
+	return strings.TrimSpace(s[:start]), strings.TrimSpace(s[start+1 : end]), s[end+1:], true
+}
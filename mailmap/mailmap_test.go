@@ -0,0 +1,170 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package mailmap_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/internal/repobuilder"
+	"codeberg.org/somebadcode/commit-tool/mailmap"
+)
+
+const testMailmap = `# Canonical identities.
+Gopher <gopher@example.com>
+<gopher@example.com> <gopher@old.example.com>
+Jane Doe <jane@example.com> <jdoe@example.com>
+Joe Bloggs <joe@example.com> Joe <shared@example.com> # Name and email.
+Ann <ann@example.com> ANN <SHARED@example.com>
+not an identity
+`
+
+func TestMailmap_Map(t *testing.T) {
+	t.Parallel()
+
+	m, err := mailmap.Parse(strings.NewReader(testMailmap))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		email     string
+		wantName  string
+		wantEmail string
+	}{
+		{
+			name:      "go",
+			email:     "gopher@example.com",
+			wantName:  "Gopher",
+			wantEmail: "gopher@example.com",
+		},
+		{
+			name:      "Gopher",
+			email:     "gopher@old.example.com",
+			wantName:  "Gopher",
+			wantEmail: "gopher@example.com",
+		},
+		{
+			name:      "J. Doe",
+			email:     "JDoe@Example.com",
+			wantName:  "Jane Doe",
+			wantEmail: "jane@example.com",
+		},
+		{
+			name:      "joe",
+			email:     "shared@example.com",
+			wantName:  "Joe Bloggs",
+			wantEmail: "joe@example.com",
+		},
+		{
+			name:      "Ann",
+			email:     "shared@example.com",
+			wantName:  "Ann",
+			wantEmail: "ann@example.com",
+		},
+		{
+			name:      "Someone",
+			email:     "shared@example.com",
+			wantName:  "Someone",
+			wantEmail: "shared@example.com",
+		},
+		{
+			name:      "Unknown",
+			email:     "unknown@example.com",
+			wantName:  "Unknown",
+			wantEmail: "unknown@example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			name, email := m.Map(tt.name, tt.email)
+			if name != tt.wantName || email != tt.wantEmail {
+				t.Errorf("Map() = %q, %q, want %q, %q", name, email, tt.wantName, tt.wantEmail)
+			}
+		})
+	}
+}
+
+func TestMailmap_Knows(t *testing.T) {
+	t.Parallel()
+
+	m, err := mailmap.Parse(strings.NewReader(testMailmap))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	for email, want := range map[string]bool{
+		"gopher@example.com":     true,
+		"Gopher@Old.Example.com": true,
+		"jane@example.com":       true,
+		"shared@example.com":     true,
+		"unknown@example.com":    false,
+	} {
+		if got := m.Knows(email); got != want {
+			t.Errorf("Knows(%q) = %t, want %t", email, got, want)
+		}
+	}
+}
+
+func TestMailmap_nil(t *testing.T) {
+	t.Parallel()
+
+	var m *mailmap.Mailmap
+
+	if name, email := m.Map("Gopher", "gopher@example.com"); name != "Gopher" || email != "gopher@example.com" {
+		t.Errorf("Map() = %q, %q, want the identity unchanged", name, email)
+	}
+
+	if m.Knows("gopher@example.com") {
+		t.Error("Knows() = true, want false")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	repo, err := repobuilder.Build(
+		repobuilder.WriteFile(mailmap.FileName, testMailmap),
+		repobuilder.Commit("chore: add mailmap", git.CommitOptions{
+			Author: &object.Signature{
+				Name:  "Gopher",
+				Email: "gopher@example.com",
+				When:  time.Date(2023, 2, 4, 23, 22, 0, 0, time.UTC),
+			},
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := mailmap.Load(repo)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if _, email := m.Map("Gopher", "gopher@old.example.com"); email != "gopher@example.com" {
+		t.Errorf("Map() email = %q, want %q", email, "gopher@example.com")
+	}
+
+	empty, err := repobuilder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = mailmap.Load(empty); err != nil {
+		t.Errorf("Load() error = %v, want nil for a repository without a mailmap", err)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package nextversion
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+)
+
+var (
+	ErrUnknownBump = errors.New("unknown version bump")
+	ErrBadBumpKey  = errors.New("expected a type or a type with a scope, e.g. chore(deps)")
+)
+
+// Bump is the part of the version that a commit bumps.
+type Bump int
+
+const (
+	BumpNone Bump = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+)
+
+var bumpNames = []string{
+	BumpNone:  "none",
+	BumpPatch: "patch",
+	BumpMinor: "minor",
+	BumpMajor: "major",
+}
+
+func (b Bump) String() string {
+	if b < 0 || int(b) >= len(bumpNames) {
+		return fmt.Sprintf("Bump(%d)", int(b))
+	}
+
+	return bumpNames[b]
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+func (b *Bump) UnmarshalText(text []byte) error {
+	i := slices.Index(bumpNames, strings.ToLower(string(text)))
+	if i < 0 {
+		return fmt.Errorf("%w %q, expected none, patch, minor or major", ErrUnknownBump, text)
+	}
+
+	*b = Bump(i)
+
+	return nil
+}
+
+// Bumps maps commit types to the part of the version that they bump. A key is a type, e.g. "feat", or a type with a
+// scope, e.g. "chore(deps)", which takes precedence over the type for commits with that scope. Commits of types that
+// aren't present don't bump the version, and breaking changes always bump the major version.
+type Bumps map[string]Bump
+
+// DefaultBumps are the bumps unless configured otherwise.
+var DefaultBumps = Bumps{
+	"feat": BumpMinor,
+	"fix":  BumpPatch,
+	"sec":  BumpPatch,
+}
+
+// Bump returns the part of the version that the commit bumps.
+func (bumps Bumps) Bump(msg commitparser.CommitMessage) Bump {
+	if msg.Breaking {
+		return BumpMajor
+	}
+
+	if msg.Scope != "" {
+		if bump, found := bumps[msg.Type+"("+msg.Scope+")"]; found {
+			return bump
+		}
+	}
+
+	return bumps[msg.Type]
+}
+
+// Types returns the types of the keys, sorted and without duplicates.
+func (bumps Bumps) Types() []string {
+	var types []string
+
+	for key := range maps.Keys(bumps) {
+		typ, _, _ := strings.Cut(key, "(")
+		types = append(types, typ)
+	}
+
+	slices.Sort(types)
+
+	return slices.Compact(types)
+}
+
+// Validate checks that every key is a type or a type with a scope.
+func (bumps Bumps) Validate() error {
+	for key := range bumps {
+		typ, scope, found := strings.Cut(key, "(")
+
+		if typ == "" || strings.ContainsAny(typ, " ()!:") ||
+			(found && (!strings.HasSuffix(scope, ")") || len(scope) < 2 || strings.ContainsAny(scope[:len(scope)-1], "()"))) {
+			return fmt.Errorf("%q: %w", key, ErrBadBumpKey)
+		}
+	}
+
+	return nil
+}
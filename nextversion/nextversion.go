@@ -37,6 +37,8 @@ type NextVersion struct {
 	Prerelease string
 	Metadata   string
 	VSuffix    bool
+	// Bumps maps commit types to the part of the version that they bump, it defaults to [DefaultBumps].
+	Bumps Bumps
 }
 
 func (nv *NextVersion) Validate() error {
@@ -56,6 +58,14 @@ func (nv *NextVersion) Validate() error {
 		nv.Writer = os.Stdout
 	}
 
+	if nv.Bumps == nil {
+		nv.Bumps = DefaultBumps
+	}
+
+	if err := nv.Bumps.Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -104,7 +114,7 @@ func (nv *NextVersion) Run(ctx context.Context) error {
 		)
 	}
 
-	var major, minor, patch bool
+	var changes []change
 
 	var version *semver.Version
 
@@ -131,18 +141,7 @@ func (nv *NextVersion) Run(ctx context.Context) error {
 			return fmt.Errorf("could not parse commit message: %w", err)
 		}
 
-		if msg.Breaking {
-			major = true
-
-			return nil
-		}
-
-		switch msg.Type {
-		case "feat":
-			minor = true
-		case "fix", "sec":
-			patch = true
-		}
+		changes = append(changes, change{hash: commit.Hash, msg: msg})
 
 		return nil
 	})
@@ -151,6 +150,19 @@ func (nv *NextVersion) Run(ctx context.Context) error {
 		return fmt.Errorf("failed to calculate next version: %w", err)
 	}
 
+	var major, minor, patch bool
+
+	for _, c := range nv.cancelReverts(ctx, changes) {
+		switch nv.Bumps.Bump(c.msg) {
+		case BumpMajor:
+			major = true
+		case BumpMinor:
+			minor = true
+		case BumpPatch:
+			patch = true
+		}
+	}
+
 	if version == nil {
 		version = semver.MustParse("0.0.0")
 	}
@@ -0,0 +1,270 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package nextversion_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/internal/repobuilder"
+	"codeberg.org/somebadcode/commit-tool/nextversion"
+)
+
+func TestNextVersion_Run_reverts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		// messages are committed after the commit tagged v1.0.0, "{n}" is replaced by the hash of the nth commit, where
+		// the tagged commit is 0.
+		messages []string
+		want     string
+	}{
+		{
+			name:     "feature",
+			messages: []string{"feat: add foo"},
+			want:     "v1.1.0",
+		},
+		{
+			name:     "git_revert",
+			messages: []string{"feat: add foo", "fix: fix bar", "Revert \"feat: add foo\"\n\nThis reverts commit {1}.\n"},
+			want:     "v1.0.1",
+		},
+		{
+			name:     "conventional_revert",
+			messages: []string{"feat: add foo", "revert: feat: add foo"},
+			want:     "v1.0.0",
+		},
+		{
+			name:     "refs_trailer",
+			messages: []string{"feat!: drop foo", "revert: bring back foo\n\nRefs: {1}\n"},
+			want:     "v1.0.0",
+		},
+		{
+			name: "revert_of_revert",
+			messages: []string{
+				"feat: add foo",
+				"Revert \"feat: add foo\"\n\nThis reverts commit {1}.\n",
+				"Revert \"Revert \"feat: add foo\"\"\n\nThis reverts commit {2}.\n",
+			},
+			want: "v1.1.0",
+		},
+		{
+			name: "reapply",
+			messages: []string{
+				"feat: add foo",
+				"Revert \"feat: add foo\"\n\nThis reverts commit {1}.\n",
+				"Reapply \"feat: add foo\"\n\nThis reverts commit {2}.\n",
+			},
+			want: "v1.1.0",
+		},
+		{
+			name:     "earlier_version",
+			messages: []string{"fix: fix bar", "Revert \"chore: init\"\n\nThis reverts commit {0}.\n"},
+			want:     "v1.0.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var hashes []string
+
+			ops := []repobuilder.OperationFunc{commit("chore: init", &hashes), tag("v1.0.0")}
+			for _, message := range tt.messages {
+				ops = append(ops, commit(message, &hashes))
+			}
+
+			repo, err := repobuilder.Build(ops...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var buf bytes.Buffer
+
+			next := nextversion.NextVersion{
+				Repository: repo,
+				Writer:     &buf,
+				VSuffix:    true,
+			}
+
+			if err = next.Run(context.Background()); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Run() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextVersion_Run_bumps(t *testing.T) {
+	t.Parallel()
+
+	bumps := nextversion.Bumps{
+		"feat":           nextversion.BumpMinor,
+		"feat(internal)": nextversion.BumpNone,
+		"fix":            nextversion.BumpPatch,
+		"perf":           nextversion.BumpPatch,
+		"chore(deps)":    nextversion.BumpPatch,
+		"api":            nextversion.BumpMinor,
+	}
+
+	tests := []struct {
+		name     string
+		messages []string
+		bumps    nextversion.Bumps
+		want     string
+	}{
+		{
+			name:     "default_bumps",
+			messages: []string{"perf: speed up foo"},
+			want:     "v1.0.0",
+		},
+		{
+			name:     "type",
+			messages: []string{"perf: speed up foo"},
+			bumps:    bumps,
+			want:     "v1.0.1",
+		},
+		{
+			name:     "custom_type",
+			messages: []string{"fix: fix foo", "api: add endpoint"},
+			bumps:    bumps,
+			want:     "v1.1.0",
+		},
+		{
+			name:     "scope",
+			messages: []string{"chore(deps): bump foo", "chore: tidy"},
+			bumps:    bumps,
+			want:     "v1.0.1",
+		},
+		{
+			name:     "scope_without_bump",
+			messages: []string{"feat(internal): add helper"},
+			bumps:    bumps,
+			want:     "v1.0.0",
+		},
+		{
+			name:     "breaking",
+			messages: []string{"feat(internal)!: drop helper"},
+			bumps:    bumps,
+			want:     "v2.0.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var hashes []string
+
+			ops := []repobuilder.OperationFunc{commit("chore: init", &hashes), tag("v1.0.0")}
+			for _, message := range tt.messages {
+				ops = append(ops, commit(message, &hashes))
+			}
+
+			repo, err := repobuilder.Build(ops...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var buf bytes.Buffer
+
+			next := nextversion.NextVersion{
+				Repository: repo,
+				Writer:     &buf,
+				VSuffix:    true,
+				Bumps:      tt.bumps,
+			}
+
+			if err = next.Run(context.Background()); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Run() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBumps_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		key     string
+		wantErr error
+	}{
+		{key: "feat"},
+		{key: "chore(deps)"},
+		{key: "", wantErr: nextversion.ErrBadBumpKey},
+		{key: "chore()", wantErr: nextversion.ErrBadBumpKey},
+		{key: "chore(deps", wantErr: nextversion.ErrBadBumpKey},
+		{key: "feat!", wantErr: nextversion.ErrBadBumpKey},
+	}
+
+	for _, tt := range tests {
+		if err := (nextversion.Bumps{tt.key: nextversion.BumpPatch}).Validate(); !errors.Is(err, tt.wantErr) {
+			t.Errorf("Validate() of %q error = %v, wantErr %v", tt.key, err, tt.wantErr)
+		}
+	}
+}
+
+// commit commits the message, with "{n}" replaced by the nth hash, and appends the hash of the commit.
+func commit(message string, hashes *[]string) repobuilder.OperationFunc {
+	return func(repo *git.Repository, tree *git.Worktree) error {
+		for n, hash := range *hashes {
+			message = strings.ReplaceAll(message, "{"+strconv.Itoa(n)+"}", hash)
+		}
+
+		err := repobuilder.Commit(message, git.CommitOptions{
+			AllowEmptyCommits: true,
+			Author: &object.Signature{
+				Name:  "Gopher",
+				Email: "gopher@example.com",
+				When:  time.Date(2023, 2, 4, 23, 22, 0, 0, time.UTC),
+			},
+		})(repo, tree)
+		if err != nil {
+			return err
+		}
+
+		head, err := repo.Head()
+		if err != nil {
+			return err
+		}
+
+		*hashes = append(*hashes, head.Hash().String())
+
+		return nil
+	}
+}
+
+func tag(name string) repobuilder.OperationFunc {
+	return func(repo *git.Repository, _ *git.Worktree) error {
+		head, err := repo.Head()
+		if err != nil {
+			return err
+		}
+
+		_, err = repo.CreateTag(name, head.Hash(), nil)
+
+		return err
+	}
+}
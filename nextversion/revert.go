@@ -0,0 +1,107 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package nextversion
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"codeberg.org/somebadcode/commit-tool/commitparser"
+)
+
+// change is a commit since the last version.
+type change struct {
+	hash plumbing.Hash
+	msg  commitparser.CommitMessage
+}
+
+// cancelReverts drops the reverts whose reverted commits are among the changes, together with the reverted commits, so
+// that a feature that is added and reverted before a release doesn't bump the version. The changes must be ordered from
+// newest to oldest, a revert of a revert then cancels out the inner revert before it's paired with its own target.
+func (nv *NextVersion) cancelReverts(ctx context.Context, changes []change) []change {
+	cancelled := make([]bool, len(changes))
+
+	for i, revert := range changes {
+		if cancelled[i] || !revert.msg.Revert {
+			continue
+		}
+
+		targets, complete := revertedChanges(changes, cancelled, i)
+		if len(targets) == 0 {
+			continue
+		}
+
+		for _, j := range targets {
+			cancelled[j] = true
+
+			if nv.Logger.Enabled(ctx, slog.LevelDebug) {
+				nv.Logger.LogAttrs(ctx, slog.LevelDebug, "revert cancels out commit",
+					slog.String("revert", revert.hash.String()),
+					slog.String("reverted", changes[j].hash.String()),
+				)
+			}
+		}
+
+		// A revert that also reverts commits of an earlier version is kept.
+		cancelled[i] = complete
+	}
+
+	kept := make([]change, 0, len(changes))
+
+	for i, c := range changes {
+		if !cancelled[i] {
+			kept = append(kept, c)
+		}
+	}
+
+	return kept
+}
+
+// revertedChanges returns the indices of the changes older than the revert at index i that it reverts, and if every
+// commit that it reverts is among them. Commits are matched by their hashes, or by the header that the revert quotes if
+// it doesn't name any.
+func revertedChanges(changes []change, cancelled []bool, i int) ([]int, bool) {
+	revert := changes[i].msg
+
+	var targets []int
+
+	if len(revert.RevertedHashes) > 0 {
+		for _, hash := range revert.RevertedHashes {
+			for j := i + 1; j < len(changes); j++ {
+				if !cancelled[j] && strings.HasPrefix(changes[j].hash.String(), hash) {
+					targets = append(targets, j)
+
+					break
+				}
+			}
+		}
+
+		return targets, len(targets) == len(revert.RevertedHashes)
+	}
+
+	if revert.Reverted == nil {
+		return nil, false
+	}
+
+	for j := i + 1; j < len(changes); j++ {
+		if !cancelled[j] && sameHeader(*revert.Reverted, changes[j].msg) {
+			return []int{j}, true
+		}
+	}
+
+	return nil, false
+}
+
+// sameHeader reports if the headers are the same, except for breaking changes, since a quoted header doesn't tell about
+// a BREAKING CHANGE trailer.
+func sameHeader(a, b commitparser.CommitMessage) bool {
+	return a.Type == b.Type && a.Scope == b.Scope && a.Subject == b.Subject
+}
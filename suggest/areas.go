@@ -0,0 +1,41 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package suggest
+
+import (
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+)
+
+// Areas map names, such as scopes or types, to the areas of the repository that they cover.
+type Areas map[string]gitignore.Matcher
+
+// NewAreas creates the areas of the names from their gitignore-style patterns, e.g. `services/api/**`. Like in a
+// gitignore file, a pattern that starts with an exclamation mark excludes what earlier patterns match.
+func NewAreas(paths map[string][]string) Areas {
+	areas := make(Areas, len(paths))
+
+	for name, patterns := range paths {
+		parsed := make([]gitignore.Pattern, 0, len(patterns))
+		for _, pattern := range patterns {
+			parsed = append(parsed, gitignore.ParsePattern(pattern, nil))
+		}
+
+		areas[name] = gitignore.NewMatcher(parsed)
+	}
+
+	return areas
+}
+
+// Covers reports if the area of the name covers the file.
+func (areas Areas) Covers(name string, file string) bool {
+	matcher, found := areas[name]
+
+	return found && matcher.Match(strings.Split(file, "/"), false)
+}
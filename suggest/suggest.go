@@ -0,0 +1,125 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+// Package suggest suggests the type and scope of a commit from the files that it changes, so that a commit helper can
+// prefill the header and the linter can tell what the type or scope probably should be.
+package suggest
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"codeberg.org/somebadcode/commit-tool/internal/changes"
+)
+
+// Suggester suggests types and scopes from the paths that they cover.
+type Suggester struct {
+	types  Areas
+	scopes Areas
+}
+
+// Suggestion is a suggested type and scope, either may be empty if none can be suggested.
+type Suggestion struct {
+	Type  string
+	Scope string
+}
+
+// New creates a suggester from types and scopes that are mapped to gitignore-style patterns, e.g. docs to `docs/**`
+// and `*.md`.
+func New(types map[string][]string, scopes map[string][]string) *Suggester {
+	return &Suggester{
+		types:  NewAreas(types),
+		scopes: NewAreas(scopes),
+	}
+}
+
+// Suggest suggests a type and a scope for a commit that changes the files. A type is only suggested if it covers every
+// file. A scope is only suggested if it's the only scope that covers any of the files, files that no scope covers are
+// ignored. Nothing is suggested if more than one type or scope would be.
+func (s *Suggester) Suggest(files []string) Suggestion {
+	if len(files) == 0 {
+		return Suggestion{}
+	}
+
+	var suggestion Suggestion
+
+	for _, t := range slices.Sorted(maps.Keys(s.types)) {
+		if !s.coversAll(t, files) {
+			continue
+		}
+
+		if suggestion.Type != "" {
+			suggestion.Type = ""
+
+			break
+		}
+
+		suggestion.Type = t
+	}
+
+	var scopes []string
+
+	for _, scope := range slices.Sorted(maps.Keys(s.scopes)) {
+		if slices.ContainsFunc(files, func(file string) bool { return s.scopes.Covers(scope, file) }) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	if len(scopes) == 1 {
+		suggestion.Scope = scopes[0]
+	}
+
+	return suggestion
+}
+
+// SuggestCommit suggests a type and a scope from the files that the commit changes. The files of a commit that hasn't
+// been created yet are the files that are staged in the repository.
+func (s *Suggester) SuggestCommit(repo *git.Repository, commit *object.Commit) (Suggestion, error) {
+	files, err := changes.Commit(repo, commit)
+	if err != nil {
+		return Suggestion{}, err
+	}
+
+	return s.Suggest(files), nil
+}
+
+// SuggestStaged suggests a type and a scope from the files that are staged to be committed in the repository.
+func (s *Suggester) SuggestStaged(repo *git.Repository) (Suggestion, error) {
+	files, err := changes.Staged(repo)
+	if err != nil {
+		return Suggestion{}, err
+	}
+
+	return s.Suggest(files), nil
+}
+
+func (s *Suggester) coversAll(t string, files []string) bool {
+	for _, file := range files {
+		if !s.types.Covers(t, file) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Header returns the start of a header with the suggested type and scope, e.g. "docs(api): ", or an empty string if
+// no type is suggested.
+func (s Suggestion) Header() string {
+	if s.Type == "" {
+		return ""
+	}
+
+	if s.Scope == "" {
+		return s.Type + ": "
+	}
+
+	return s.Type + "(" + s.Scope + "): "
+}
@@ -0,0 +1,84 @@
+/*
+ * This file is part of commit-tool which is released under EUPL 1.2.
+ * See the file LICENSE in the repository root for full license details.
+ *
+ * SPDX-License-Identifier: EUPL-1.2
+ */
+
+package suggest_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"codeberg.org/somebadcode/commit-tool/suggest"
+)
+
+func TestSuggester_Suggest(t *testing.T) {
+	t.Parallel()
+
+	suggester := suggest.New(
+		map[string][]string{
+			"docs": {"docs/**", "*.md"},
+			"ci":   {".github/**"},
+			"test": {"*_test.go", "testdata/"},
+		},
+		map[string][]string{
+			"api": {"services/api/**"},
+			"web": {"services/web/**"},
+		},
+	)
+
+	tests := []struct {
+		name       string
+		files      []string
+		want       suggest.Suggestion
+		wantHeader string
+	}{
+		{
+			name:       "docs_only",
+			files:      []string{"README.md", "docs/usage.md"},
+			want:       suggest.Suggestion{Type: "docs"},
+			wantHeader: "docs: ",
+		},
+		{
+			name:       "docs_of_scope",
+			files:      []string{"services/api/README.md"},
+			want:       suggest.Suggestion{Type: "docs", Scope: "api"},
+			wantHeader: "docs(api): ",
+		},
+		{
+			name:  "scope_only",
+			files: []string{"services/web/index.html", "go.mod"},
+			want:  suggest.Suggestion{Scope: "web"},
+		},
+		{
+			name:  "mixed",
+			files: []string{"docs/usage.md", "services/api/main.go", "services/web/index.html"},
+		},
+		{
+			name:  "ambiguous_type",
+			files: []string{"testdata/README.md"},
+		},
+		{
+			name: "no_files",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := suggester.Suggest(tt.files)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Suggest() mismatch (-want +got):\n%s", diff)
+			}
+
+			if header := got.Header(); header != tt.wantHeader {
+				t.Errorf("Header() = %q, want %q", header, tt.wantHeader)
+			}
+		})
+	}
+}